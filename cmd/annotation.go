@@ -0,0 +1,104 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lstuker/grafana-tool/grafana"
+	"github.com/spf13/cobra"
+)
+
+var annotationDashboardUID string
+var annotationPanelID int
+var annotationTags []string
+var annotationFrom string
+var annotationTo string
+var annotationType string
+var annotationLimit int
+
+// annotationCmd represents the annotation command
+var annotationCmd = &cobra.Command{
+	Use:   "annotation",
+	Short: "Manage Grafana annotations",
+	Long:  `Manage Grafana annotations`,
+}
+
+func init() {
+	rootCmd.AddCommand(annotationCmd)
+}
+
+// addAnnotationFilterFlags adds the flags used to select annotations
+func addAnnotationFilterFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&annotationDashboardUID, "dashboard-uid", "", "Only annotations of the dashboard with this UID")
+	cmd.Flags().IntVar(&annotationPanelID, "panel", 0, "Only annotations of this panel ID")
+	cmd.Flags().StringSliceVar(&annotationTags, "tag", nil, "Only annotations with this tag (can be repeated)")
+	cmd.Flags().StringVar(&annotationFrom, "from", "", "Start of the time range, ex: now-7d or 2019-03-01")
+	cmd.Flags().StringVar(&annotationTo, "to", "", "End of the time range, ex: now or 2019-03-31")
+	cmd.Flags().StringVar(&annotationType, "type", "", "Only annotations of this type: alert or annotation")
+	cmd.Flags().IntVar(&annotationLimit, "limit", 1000, "Maximum number of annotations")
+}
+
+// annotationQuery builds the annotation query from the filter flags
+func annotationQuery() grafana.AnnotationQuery {
+	query := grafana.AnnotationQuery{
+		DashboardUID: annotationDashboardUID,
+		PanelID:      annotationPanelID,
+		Tags:         annotationTags,
+		Type:         annotationType,
+		Limit:        annotationLimit,
+	}
+
+	now := time.Now()
+	var err error
+	if annotationFrom != "" {
+		query.From, err = grafana.ParseTime(annotationFrom, now)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+	if annotationTo != "" {
+		query.To, err = grafana.ParseTime(annotationTo, now)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+	return query
+}
+
+// annotationRows returns the table rows for a list of annotations
+func annotationRows(annotations grafana.AnnotationListJSON) [][]string {
+	rows := [][]string{}
+	for _, a := range annotations {
+		text := strings.Replace(a.Text, "\n", " ", -1)
+		if runes := []rune(text); len(runes) > 60 {
+			text = string(runes[:57]) + "..."
+		}
+		rows = append(rows, []string{
+			strconv.Itoa(a.ID),
+			time.Unix(0, a.Time*int64(time.Millisecond)).Format(time.RFC3339),
+			a.DashboardUID,
+			strconv.Itoa(a.PanelID),
+			strings.Join(a.Tags, ","),
+			text,
+		})
+	}
+	return rows
+}
+
+var annotationHeader = []string{"ID", "TIME", "DASHBOARD", "PANEL", "TAGS", "TEXT"}
@@ -0,0 +1,65 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"log"
+
+	"github.com/spf13/cobra"
+)
+
+var annotationDryRun bool
+
+// annotationDeleteCmd represents the annotation delete command
+var annotationDeleteCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Deletes all annotations matching the filters",
+	Run: func(cmd *cobra.Command, args []string) {
+		deleteAnnotations()
+	},
+}
+
+func init() {
+	annotationCmd.AddCommand(annotationDeleteCmd)
+	addAnnotationFilterFlags(annotationDeleteCmd)
+	annotationDeleteCmd.Flags().BoolVar(&annotationDryRun, "dry-run", false, "Only show the annotations that would be deleted")
+}
+
+func deleteAnnotations() {
+	if annotationDashboardUID == "" && annotationPanelID == 0 && len(annotationTags) == 0 &&
+		annotationFrom == "" && annotationTo == "" && annotationType == "" {
+		log.Fatal("Refusing to delete without a filter, use --dashboard-uid, --panel, --tag, --from, --to or --type")
+	}
+
+	c := newClient()
+	annotations, err := c.GetAnnotations(annotationQuery())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	printTable(annotationHeader, annotationRows(annotations))
+	if annotationDryRun {
+		log.Printf("Dry run: %d annotations would be deleted\n", len(annotations))
+		return
+	}
+
+	for _, annotation := range annotations {
+		err = c.DeleteAnnotation(annotation.ID)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+	log.Printf("Deleted %d annotations\n", len(annotations))
+}
@@ -0,0 +1,60 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"log"
+
+	"github.com/spf13/cobra"
+)
+
+var annotationExportPath string
+
+// annotationExportCmd represents the annotation export command
+var annotationExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Exports annotations to a JSON file",
+	Run: func(cmd *cobra.Command, args []string) {
+		exportAnnotations()
+	},
+}
+
+func init() {
+	annotationCmd.AddCommand(annotationExportCmd)
+	addAnnotationFilterFlags(annotationExportCmd)
+	annotationExportCmd.Flags().StringVarP(&annotationExportPath, "path", "p", "", "File to save annotations (required)")
+	annotationExportCmd.MarkFlagRequired("path")
+}
+
+func exportAnnotations() {
+	c := newClient()
+	annotations, err := c.GetAnnotations(annotationQuery())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	data, err := json.MarshalIndent(annotations, "", "  ")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	log.Printf("Writing %d annotations to: %s\n", len(annotations), annotationExportPath)
+	err = ioutil.WriteFile(annotationExportPath, data, 0644)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
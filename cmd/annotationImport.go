@@ -0,0 +1,62 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"log"
+
+	"github.com/lstuker/grafana-tool/grafana"
+	"github.com/spf13/cobra"
+)
+
+var annotationImportPath string
+
+// annotationImportCmd represents the annotation import command
+var annotationImportCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Imports annotations from a JSON file created by annotation export",
+	Run: func(cmd *cobra.Command, args []string) {
+		importAnnotations()
+	},
+}
+
+func init() {
+	annotationCmd.AddCommand(annotationImportCmd)
+	annotationImportCmd.Flags().StringVarP(&annotationImportPath, "path", "p", "", "File with the exported annotations (required)")
+	annotationImportCmd.MarkFlagRequired("path")
+}
+
+func importAnnotations() {
+	data, err := ioutil.ReadFile(annotationImportPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var annotations grafana.AnnotationListJSON
+	err = json.Unmarshal(data, &annotations)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	c := newClient()
+	for _, annotation := range annotations {
+		id, err := c.CreateAnnotation(annotation)
+		if err != nil {
+			log.Fatal(err)
+		}
+		log.Printf("Created annotation %d (was %d)\n", id, annotation.ID)
+	}
+}
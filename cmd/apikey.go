@@ -0,0 +1,55 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/lstuker/grafana-tool/grafana"
+	"github.com/spf13/cobra"
+)
+
+// apikeyCmd represents the apikey command
+var apikeyCmd = &cobra.Command{
+	Use:   "apikey",
+	Short: "Audit and migrate legacy Grafana API keys",
+	Long: `Audit and migrate legacy Grafana API keys
+
+API keys are replaced by service account tokens in newer Grafana versions.`,
+}
+
+func init() {
+	rootCmd.AddCommand(apikeyCmd)
+}
+
+var apiKeyHeader = []string{"ID", "NAME", "ROLE", "AGE", "LAST USED", "EXPIRES"}
+
+// apiKeyRow returns the report columns of an API key
+func apiKeyRow(key grafana.APIKeyJSON, now time.Time) []string {
+	age := "unknown"
+	if key.Created != nil && !key.Created.IsZero() {
+		age = fmt.Sprintf("%dd", int(now.Sub(*key.Created).Hours()/24))
+	}
+	return []string{
+		strconv.Itoa(key.ID),
+		key.Name,
+		key.Role,
+		age,
+		formatOptionalTime(key.LastUsedAt, "never"),
+		formatOptionalTime(key.Expiration, "never"),
+	}
+}
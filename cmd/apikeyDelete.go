@@ -0,0 +1,52 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"log"
+
+	"github.com/spf13/cobra"
+)
+
+// apikeyDeleteCmd represents the apikey delete command
+var apikeyDeleteCmd = &cobra.Command{
+	Use:   "delete NAME_OR_ID",
+	Short: "Deletes an API key",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		deleteAPIKey(args[0])
+	},
+}
+
+func init() {
+	apikeyCmd.AddCommand(apikeyDeleteCmd)
+}
+
+func deleteAPIKey(nameOrID string) {
+	c := newClient()
+	keys, err := c.GetAPIKeys()
+	if err != nil {
+		log.Fatal(err)
+	}
+	key, err := keys.APIKeyFind(nameOrID)
+	if err != nil {
+		log.Fatalf("%s: %s", err, nameOrID)
+	}
+	err = c.DeleteAPIKey(key.ID)
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("Deleted API key %s\n", key.Name)
+}
@@ -0,0 +1,50 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"log"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// apikeyListCmd represents the apikey list command
+var apikeyListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists API keys with role, age and last use",
+	Run: func(cmd *cobra.Command, args []string) {
+		listAPIKeys()
+	},
+}
+
+func init() {
+	apikeyCmd.AddCommand(apikeyListCmd)
+}
+
+func listAPIKeys() {
+	c := newClient()
+	keys, err := c.GetAPIKeys()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	now := time.Now()
+	rows := [][]string{}
+	for _, key := range keys {
+		rows = append(rows, apiKeyRow(key, now))
+	}
+	printList(apiKeyHeader, rows, keys, 0)
+}
@@ -0,0 +1,119 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/lstuker/grafana-tool/grafana"
+	"github.com/spf13/cobra"
+)
+
+var apikeyMigrateDryRun bool
+var apikeyMigrateRecreate bool
+var apikeyMigrateKeyDir string
+
+// apikeyMigrateCmd represents the apikey migrate command
+var apikeyMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Migrates all API keys to service accounts",
+	Long: `Migrates all API keys to service accounts and reports every key with its
+role, age, last use and the migration result.
+
+By default the Grafana migration endpoint is used, which converts a key into
+a service account token so clients keep working. Grafana versions without
+this endpoint can use --recreate: a service account with the role of the key
+is created together with a new token, which is written to --key-dir as
+ID_NAME.key with the ID of the key and its name as slug. Those new tokens
+must be rolled out to the clients before the keys are deleted.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		migrateAPIKeys()
+	},
+}
+
+func init() {
+	apikeyCmd.AddCommand(apikeyMigrateCmd)
+	apikeyMigrateCmd.Flags().BoolVar(&apikeyMigrateDryRun, "dry-run", false, "Only report the API keys")
+	apikeyMigrateCmd.Flags().BoolVar(&apikeyMigrateRecreate, "recreate", false, "Create equivalent service accounts when Grafana can not migrate a key")
+	apikeyMigrateCmd.Flags().StringVar(&apikeyMigrateKeyDir, "key-dir", "", "Directory for the tokens created by --recreate")
+}
+
+func migrateAPIKeys() {
+	if apikeyMigrateRecreate && apikeyMigrateKeyDir == "" {
+		log.Fatal("--recreate needs --key-dir to save the new tokens")
+	}
+
+	c := newClient()
+	keys, err := c.GetAPIKeys()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	now := time.Now()
+	rows := [][]string{}
+	for _, key := range keys {
+		result := "not migrated (dry run)"
+		if !apikeyMigrateDryRun {
+			result = migrateAPIKey(c, key, now)
+		}
+		rows = append(rows, append(apiKeyRow(key, now), result))
+	}
+	printTable(append(apiKeyHeader, "RESULT"), rows)
+}
+
+// migrateAPIKey migrates one key and returns the result for the report
+func migrateAPIKey(c *grafana.Client, key grafana.APIKeyJSON, now time.Time) string {
+	err := c.MigrateAPIKey(key.ID)
+	if err == nil {
+		return "migrated"
+	}
+	if err != grafana.ErrMigrationNotSupported || !apikeyMigrateRecreate {
+		return "failed: " + err.Error()
+	}
+
+	secondsToLive := 0
+	if key.Expiration != nil {
+		secondsToLive = int(key.Expiration.Sub(now).Seconds())
+		if secondsToLive <= 0 {
+			return "skipped: expired"
+		}
+	}
+
+	// the key name is chosen by users, only its slug is used in the file name
+	file := filepath.Join(apikeyMigrateKeyDir, fmt.Sprintf("%d_%s.key", key.ID, grafana.Slug(key.Name)))
+	if filepath.Dir(file) != filepath.Clean(apikeyMigrateKeyDir) {
+		return "failed: key file " + file + " is outside of " + apikeyMigrateKeyDir
+	}
+
+	account, err := c.CreateServiceAccount("apikey-"+key.Name, key.Role)
+	if err != nil {
+		return "failed: " + err.Error()
+	}
+	token, err := c.CreateServiceAccountToken(account.ID, key.Name, secondsToLive)
+	if err != nil {
+		return "failed: " + err.Error()
+	}
+
+	err = os.MkdirAll(apikeyMigrateKeyDir, 0700)
+	if err != nil {
+		log.Fatal(err)
+	}
+	writeSecretFile(file, token.Key)
+	return "recreated as service account " + account.Name
+}
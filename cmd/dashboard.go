@@ -14,7 +14,15 @@
 
 package cmd
 
-import "github.com/spf13/cobra"
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+
+	"github.com/lstuker/grafana-tool/grafana"
+	"github.com/spf13/cobra"
+)
 
 // dashboardCmd represents the dashboard command
 var dashboardCmd = &cobra.Command{
@@ -26,3 +34,33 @@ var dashboardCmd = &cobra.Command{
 func init() {
 	rootCmd.AddCommand(dashboardCmd)
 }
+
+// readDashboardFile reads a dashboard from a file written by dashboard export
+// or from a file with meta data as returned by the Grafana API
+func readDashboardFile(file string) (map[string]interface{}, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var dashboard map[string]interface{}
+	err = json.Unmarshal(data, &dashboard)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", file, err)
+	}
+	if inner, ok := dashboard["dashboard"].(map[string]interface{}); ok {
+		return inner, nil
+	}
+	return dashboard, nil
+}
+
+// writeDashboardFile writes a changed dashboard document back to the file it
+// was read from, formatted like the original data of the file
+func writeDashboardFile(file string, original []byte, document map[string]interface{}) error {
+	data, err := grafana.ReencodeJSON(original, document)
+	if err != nil {
+		return fmt.Errorf("%s: %s", file, err)
+	}
+	log.Printf("Writing dashboard to: %s\n", file)
+	return ioutil.WriteFile(file, data, 0644)
+}
@@ -0,0 +1,164 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"strings"
+
+	"github.com/lstuker/grafana-tool/grafana"
+	"github.com/spf13/cobra"
+)
+
+var convertPanelsSelector dashboardSelector
+var convertPanelsTypes []string
+var convertPanelsDryRun bool
+var convertPanelsYes bool
+
+// dashboardConvertPanelsCmd represents the dashboard convert-panels command
+var dashboardConvertPanelsCmd = &cobra.Command{
+	Use:   "convert-panels [FILE...]",
+	Short: "Converts deprecated panels to their successors",
+	Long: `Converts the deprecated panel types of dashboard files in place or of the
+dashboards selected by UID, title, title regex, folder or tag:
+
+  graph                   timeseries
+  singlestat              stat, or gauge if the gauge is shown
+  table-old               table
+  grafana-worldmap-panel  geomap
+
+Axes, legend, thresholds, value and range maps, sparklines and column styles
+are translated to options and field config. Options which can not be
+translated are listed per panel and need a review. Dashboard files keep their
+key order and indentation. Saving dashboards on Grafana has to be confirmed
+unless --yes is given.
+
+ex: grafana-tool dashboard convert-panels --type singlestat linux/*.json
+ex: grafana-tool dashboard convert-panels --folder Linux --dry-run`,
+	Run: func(cmd *cobra.Command, args []string) {
+		convertPanels(args)
+	},
+}
+
+func init() {
+	dashboardCmd.AddCommand(dashboardConvertPanelsCmd)
+	addDashboardSelectFlags(dashboardConvertPanelsCmd, &convertPanelsSelector)
+	dashboardConvertPanelsCmd.Flags().StringSliceVar(&convertPanelsTypes, "type", []string{}, "Convert only these panel types: "+strings.Join(grafana.DeprecatedPanelTypes(), ", "))
+	dashboardConvertPanelsCmd.Flags().BoolVar(&convertPanelsDryRun, "dry-run", false, "Only show the panels which would be converted")
+	dashboardConvertPanelsCmd.Flags().BoolVarP(&convertPanelsYes, "yes", "y", false, "Save on Grafana without confirmation, for scripts")
+}
+
+func convertPanels(files []string) {
+	for _, panelType := range convertPanelsTypes {
+		if !contains(grafana.DeprecatedPanelTypes(), panelType) {
+			log.Fatalf("Invalid panel type %s, use %s", panelType, strings.Join(grafana.DeprecatedPanelTypes(), ", "))
+		}
+	}
+	if len(files) == 0 && convertPanelsSelector.empty() {
+		log.Fatal("Give dashboard files or select dashboards with --uid, --title, --title-regex, --folder or --tag")
+	}
+
+	for _, file := range files {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			log.Fatal(err)
+		}
+		var document map[string]interface{}
+		err = json.Unmarshal(data, &document)
+		if err != nil {
+			log.Fatalf("%s: %s", file, err)
+		}
+		dashboard := document
+		if inner, ok := document["dashboard"].(map[string]interface{}); ok {
+			dashboard = inner
+		}
+
+		conversions := grafana.ConvertDashboardPanels(dashboard, convertPanelsTypes)
+		printPanelConversions(file, conversions)
+		if convertPanelsDryRun || len(conversions) == 0 {
+			continue
+		}
+
+		err = writeDashboardFile(file, data, document)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if convertPanelsSelector.empty() {
+		return
+	}
+	c := newClient()
+	changed := []grafana.DashboardSaveJSON{}
+	for _, result := range selectDashboards(c, convertPanelsSelector) {
+		raw, err := c.GetDashboardRawByUID(result.UID)
+		if err != nil {
+			log.Fatal(err)
+		}
+		conversions := grafana.ConvertDashboardPanels(raw.Dashboard, convertPanelsTypes)
+		printPanelConversions(result.UID, conversions)
+		if len(conversions) == 0 {
+			continue
+		}
+		changed = append(changed, grafana.DashboardSaveJSON{
+			Dashboard: raw.Dashboard,
+			FolderID:  raw.Meta.FolderID,
+			FolderUID: raw.Meta.FolderUID,
+			Message:   fmt.Sprintf("Converted %d deprecated panels", len(conversions)),
+		})
+	}
+
+	if convertPanelsDryRun || len(changed) == 0 {
+		return
+	}
+	if !convertPanelsYes && !confirm(fmt.Sprintf("Save %d dashboards on Grafana?", len(changed))) {
+		log.Println("Aborted")
+		return
+	}
+
+	failures := [][]string{}
+	for _, dashboard := range changed {
+		fields, _ := dashboard.Dashboard.(map[string]interface{})
+		uid, _ := fields["uid"].(string)
+		title, _ := fields["title"].(string)
+		_, err := c.SaveDashboard(dashboard)
+		if err != nil {
+			failures = append(failures, []string{uid, title, err.Error()})
+			continue
+		}
+		log.Printf("Saved dashboard %s (%s)\n", title, uid)
+	}
+
+	if len(failures) > 0 {
+		log.Printf("%d dashboards could not be saved:\n", len(failures))
+		printTable([]string{"UID", "TITLE", "ERROR"}, failures)
+		log.Fatal("Conversion incomplete")
+	}
+}
+
+// printPanelConversions logs the converted panels of a dashboard with the
+// options which could not be translated
+func printPanelConversions(source string, conversions []grafana.PanelConversion) {
+	for _, conversion := range conversions {
+		panel := fmt.Sprintf("%s: panel %v %q", source, conversion.PanelID, conversion.Title)
+		log.Printf("%s: %s to %s\n", panel, conversion.From, conversion.To)
+		for _, option := range conversion.Untranslated {
+			log.Printf("%s: not translated: %s\n", panel, option)
+		}
+	}
+}
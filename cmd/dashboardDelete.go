@@ -0,0 +1,141 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/lstuker/grafana-tool/grafana"
+	"github.com/spf13/cobra"
+)
+
+var deleteSelector dashboardSelector
+var deleteYes bool
+var deleteForce bool
+var deleteTrash string
+
+// dashboardDeleteCmd represents the dashboard delete command
+var dashboardDeleteCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Deletes dashboards after a preview and a backup to the trash directory",
+	Long: `Deletes the dashboards selected by UID, title, title regex, folder or tag. The selected
+dashboards are always shown first and have to be confirmed unless --yes is
+given. Every dashboard is saved to the trash directory before it is deleted.
+Provisioned dashboards are refused unless --force is given, provisioning
+would create them again.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		deleteDashboards()
+	},
+}
+
+func init() {
+	dashboardCmd.AddCommand(dashboardDeleteCmd)
+	addDashboardSelectFlags(dashboardDeleteCmd, &deleteSelector)
+	dashboardDeleteCmd.Flags().BoolVarP(&deleteYes, "yes", "y", false, "Delete without confirmation, for scripts")
+	dashboardDeleteCmd.Flags().BoolVar(&deleteForce, "force", false, "Delete provisioned dashboards too")
+	dashboardDeleteCmd.Flags().StringVar(&deleteTrash, "trash", "grafana-trash", "Directory to save the deleted dashboards")
+}
+
+func deleteDashboards() {
+	if deleteSelector.empty() {
+		log.Fatal("Select the dashboards with --uid, --title, --title-regex, --folder or --tag")
+	}
+
+	c := newClient()
+	selected := selectDashboards(c, deleteSelector)
+	if len(selected) == 0 {
+		log.Println("No dashboards selected")
+		return
+	}
+
+	dashboards := []grafana.DashboardRawJSON{}
+	rows := [][]string{}
+	provisioned := 0
+	for _, result := range selected {
+		dashboard, err := c.GetDashboardRawByUID(result.UID)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if dashboard.Meta.Provisioned {
+			provisioned++
+		}
+		dashboards = append(dashboards, dashboard)
+		rows = append(rows, []string{result.UID, result.Title, result.FolderTitle, strconv.FormatBool(dashboard.Meta.Provisioned)})
+	}
+
+	log.Printf("%d dashboards will be deleted:\n", len(dashboards))
+	printTable([]string{"UID", "TITLE", "FOLDER", "PROVISIONED"}, rows)
+
+	if provisioned > 0 && !deleteForce {
+		log.Fatalf("%d dashboards are provisioned and would be created again, use --force to delete them anyway", provisioned)
+	}
+	if !deleteYes && !confirm(fmt.Sprintf("Delete %d dashboards?", len(dashboards))) {
+		log.Println("Aborted")
+		return
+	}
+
+	err := os.MkdirAll(deleteTrash, 0755)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	failed := 0
+	for _, dashboard := range dashboards {
+		uid, _ := dashboard.Dashboard["uid"].(string)
+		title, _ := dashboard.Dashboard["title"].(string)
+
+		data, err := json.MarshalIndent(dashboard, "", "  ")
+		if err != nil {
+			log.Fatal(err)
+		}
+		filePath := fmt.Sprintf("%s/%s_%s_v%d.json", strings.TrimRight(deleteTrash, "/"), grafana.DashboardJSON{Title: title}.TitelForFile(), uid, dashboard.Meta.Version)
+		log.Printf("Writing dashboard to: %s\n", filePath)
+		err = ioutil.WriteFile(filePath, data, 0644)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		err = c.DeleteDashboardByUID(uid)
+		if err != nil {
+			log.Printf("Could not delete dashboard %s: %s\n", uid, err)
+			failed++
+			continue
+		}
+		log.Printf("Deleted dashboard %s (%s)\n", title, uid)
+	}
+
+	if failed > 0 {
+		log.Fatalf("%d dashboards could not be deleted", failed)
+	}
+}
+
+// confirm asks the question on stdin and returns true if it is answered
+// with y or yes
+func confirm(question string) bool {
+	fmt.Printf("%s [y/N] ", question)
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && answer == "" {
+		return false
+	}
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
@@ -0,0 +1,177 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"regexp"
+	"strconv"
+
+	"github.com/lstuker/grafana-tool/grafana"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var diffUID string
+
+// dashboardSourceRegexp matches [profile:]UID[@version] and [profile:]@version
+var dashboardSourceRegexp = regexp.MustCompile(`^(?:([^:@]+):)?([^:@]*)(?:@(\d+))?$`)
+
+// dashboardDiffCmd represents the dashboard diff command
+var dashboardDiffCmd = &cobra.Command{
+	Use:   "diff SOURCE SOURCE",
+	Short: "Shows the changes between two dashboards panel by panel",
+	Long: `Shows the added, removed, moved and changed panels, the changed queries,
+variables and settings between two dashboards. Volatile fields like the
+version and the order of the panels are ignored. A source is one of:
+
+  FILE            a dashboard file, ex: written by dashboard export
+  UID             the dashboard on the configured Grafana
+  PROFILE:UID     the dashboard on the Grafana of a profile in the config file
+  UID@VERSION     a version of the dashboard, PROFILE:UID@VERSION works too
+  @VERSION        a version of the dashboard given by the other source or --uid
+
+Use --output json for a machine readable result. Exits with 1 if the
+dashboards differ and with 2 on errors.
+
+ex: grafana-tool dashboard diff linux/linux_memory_dashboard.json 000000012
+    grafana-tool dashboard diff staging:000000012 production:000000012
+    grafana-tool dashboard diff 000000012@11 @12`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		diffDashboards(args[0], args[1])
+	},
+}
+
+func init() {
+	dashboardCmd.AddCommand(dashboardDiffCmd)
+	dashboardDiffCmd.Flags().StringVar(&diffUID, "uid", "", "Dashboard UID for sources which are only a version")
+}
+
+func diffDashboards(sourceA, sourceB string) {
+	uidA := dashboardSourceUID(sourceA)
+	uidB := dashboardSourceUID(sourceB)
+	if diffUID != "" {
+		uidA, uidB = diffUID, diffUID
+	}
+	if uidA == "" {
+		uidA = uidB
+	}
+	if uidB == "" {
+		uidB = uidA
+	}
+
+	a := loadDashboardSource(sourceA, uidA)
+	b := loadDashboardSource(sourceB, uidB)
+	diff := grafana.DiffDashboards(a, b)
+
+	if outputFormat == "json" || outputFormat == "yaml" {
+		printRecords(diff)
+	} else if !diff.Empty() {
+		fmt.Printf("--- %s\n+++ %s\n", sourceA, sourceB)
+		fmt.Print(diff)
+	}
+
+	if !diff.Empty() {
+		os.Exit(1)
+	}
+}
+
+// dashboardSourceUID returns the UID of a source, which is empty for a
+// version number
+func dashboardSourceUID(source string) string {
+	if isFile(source) {
+		dashboard, err := readDashboardFile(source)
+		if err != nil {
+			diffFatal(err)
+		}
+		uid, _ := dashboard["uid"].(string)
+		return uid
+	}
+	return parseDashboardSource(source)[2]
+}
+
+// parseDashboardSource returns the profile, UID and version of a source
+func parseDashboardSource(source string) []string {
+	match := dashboardSourceRegexp.FindStringSubmatch(source)
+	if match == nil || (match[2] == "" && match[3] == "") {
+		diffFatalf("Invalid source %s, use FILE, UID, PROFILE:UID, UID@VERSION or @VERSION", source)
+	}
+	return match
+}
+
+// loadDashboardSource returns the dashboard of a source, uid is used for a
+// source which is only a version number
+func loadDashboardSource(source string, uid string) map[string]interface{} {
+	if isFile(source) {
+		dashboard, err := readDashboardFile(source)
+		if err != nil {
+			diffFatal(err)
+		}
+		return dashboard
+	}
+
+	match := parseDashboardSource(source)
+	if match[2] == "" {
+		if uid == "" {
+			diffFatalf("Unknown dashboard for version %s, use --uid", source)
+		}
+		match[2] = uid
+	}
+
+	c := newClient()
+	if match[1] != "" {
+		if !viper.IsSet("profiles." + match[1]) {
+			diffFatalf("Profile %s not found in the config file", match[1])
+		}
+		c = profileClient(match[1])
+	}
+	if match[3] != "" {
+		version, err := strconv.Atoi(match[3])
+		if err != nil {
+			diffFatalf("Invalid version %s", match[3])
+		}
+		dashboardVersion, err := c.GetDashboardVersion(match[2], version)
+		if err != nil {
+			diffFatal(err)
+		}
+		return dashboardVersion.Data
+	}
+	dashboard, err := c.GetDashboardRawByUID(match[2])
+	if err != nil {
+		diffFatal(err)
+	}
+	return dashboard.Dashboard
+}
+
+// diffFatal logs the error and exits with 2, 1 means the dashboards differ
+func diffFatal(v ...interface{}) {
+	log.Print(v...)
+	os.Exit(2)
+}
+
+// diffFatalf is diffFatal with a format
+func diffFatalf(format string, v ...interface{}) {
+	log.Printf(format, v...)
+	os.Exit(2)
+}
+
+// isFile returns true if file exists and is not a directory
+func isFile(file string) bool {
+	info, err := os.Stat(file)
+	return err == nil && !info.IsDir()
+}
@@ -15,14 +15,16 @@
 package cmd
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"log"
-	"net/http"
 	"os"
-	"strconv"
+	"path/filepath"
+	"sort"
 	"strings"
+	"text/template"
 
 	"github.com/lstuker/grafana-tool/grafana"
 	"github.com/spf13/cobra"
@@ -30,11 +32,24 @@ import (
 
 var path string
 var folderName string
+var exportWithLibraryPanels bool
+var exportWithHistory bool
+var exportLayout string
+var exportMirror bool
+var exportCanonical bool
+var exportStale string
+var exportNoPublicReport bool
 
 // dashboardExportCmd represents the dashboardExport command
 var dashboardExportCmd = &cobra.Command{
 	Use:   "export",
 	Short: "Exports all dashboard of a Grafana folder",
+	Long: `Exports all dashboards or the dashboards of a Grafana folder. The file names
+are built with the --layout template, the default groups the dashboards by the
+first word of the title. Nothing is written if two dashboards would get the
+same file name.
+
+ex: grafana-tool dashboard export --path dashboards --layout "{{.Folder}}/{{.Slug}}-{{.UID}}.json"`,
 	Run: func(cmd *cobra.Command, args []string) {
 		exportDashboard()
 	},
@@ -45,48 +60,266 @@ func init() {
 	dashboardExportCmd.Flags().StringVarP(&path, "path", "p", "", "Path to save dashboards (required)")
 	dashboardExportCmd.MarkFlagRequired("path")
 	dashboardExportCmd.Flags().StringVarP(&folderName, "folder", "f", "", "Grafana folder name. Dashboards of this folder will be exported")
+	dashboardExportCmd.Flags().BoolVar(&exportWithHistory, "history", false, "Export all versions of each dashboard next to it for audits")
+	dashboardExportCmd.Flags().StringVar(&exportLayout, "layout", "{{.FirstWord}}/{{.Slug}}_dashboard.json", "File name template relative to --path with the fields .UID, .Title, .Slug, .FirstWord, .Folder, .FolderUID, .Tag and .Tags")
+	dashboardExportCmd.Flags().BoolVar(&exportWithLibraryPanels, "library-panels", false, "Export the library panels used by the dashboards to <path>/library-panels")
+	dashboardExportCmd.Flags().BoolVar(&exportCanonical, "canonical", false, "Write dashboards complete and in a stable form without volatile fields, see dashboard fmt")
+	dashboardExportCmd.Flags().BoolVar(&exportMirror, "mirror", false, "Keep --path a mirror of Grafana: skip unchanged files and handle files of deleted or renamed dashboards")
+	dashboardExportCmd.Flags().BoolVar(&exportNoPublicReport, "no-public-report", false, "Do not list the exported dashboards which are shared publicly in <path>/public_dashboards.json")
+	dashboardExportCmd.Flags().StringVar(&exportStale, "stale", "move", "What --mirror does with files of deleted or renamed dashboards: move to <path>/.stale or delete")
 
 }
 
 func exportDashboard() {
-	c := grafana.NewClient(grafanaURL, apiToken, username, password, http.DefaultClient)
-	folderID := ""
+	if exportStale != "move" && exportStale != "delete" {
+		log.Fatalf("Invalid --stale %s, use move or delete", exportStale)
+	}
+	layout, err := template.New("layout").Option("missingkey=error").Parse(exportLayout)
+	if err != nil {
+		log.Fatalf("Invalid --layout: %s", err)
+	}
+
+	c := newClient()
+	query := grafana.SearchQuery{Type: "dash-db"}
 	if folderName != "" {
-		folders, err := c.GetFolders()
-		if err != nil {
-			log.Fatal(err)
-		}
-		folder, err := folders.FolderFindByName(folderName)
-		folderID = strconv.Itoa(folder.ID)
+		query.FolderIDs = []int{findFolder(c, folderName).ID}
 	}
 
-	searchResults, err := c.SearchDashboard("", folderID, "dash-db")
+	searchResults, err := c.Search(query)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	for _, result := range searchResults {
-		dashboardFull, err := c.GetDashboardByUID(result.UID)
+	// all file names are known before anything is written, so dashboards
+	// can not overwrite each other
+	files := make([]string, len(searchResults))
+	for i, result := range searchResults {
+		files[i], err = exportFileName(layout, newExportLayoutData(result.UID, result.Title, result.FolderUID, result.FolderTitle, result.Tags))
 		if err != nil {
 			log.Fatal(err)
 		}
-		dash, err := json.MarshalIndent(dashboardFull.Dashboard, "", "  ")
+	}
+	checkExportCollisions(files, searchResults)
+
+	manifest := exportManifest{Dashboards: map[string]exportManifestEntry{}}
+	libraryPanels := map[string]bool{}
+	for i, result := range searchResults {
+		dashboardRaw, err := c.GetDashboardRawByUID(result.UID)
 		if err != nil {
 			log.Fatal(err)
 		}
-		filePath := fmt.Sprintf("%s/%s/%s_dashboard.json", path, dashboardFull.Dashboard.TitelFirstWord(), dashboardFull.Dashboard.TitelForFile())
-
-		dashboardPath := strings.TrimRight(path, "/") + "/" + dashboardFull.Dashboard.TitelFirstWord()
-		err = os.MkdirAll(dashboardPath, 0755)
+		dashboard, err := typedDashboard(dashboardRaw.Dashboard)
+		if err != nil {
+			log.Fatalf("%s: %s", result.UID, err)
+		}
+		var dash []byte
+		if exportCanonical {
+			dash, err = grafana.CanonicalJSON(grafana.Canonicalize(dashboardRaw.Dashboard))
+		} else {
+			dash, err = json.MarshalIndent(dashboard, "", "  ")
+		}
 		if err != nil {
 			log.Fatal(err)
 		}
+		filePath := filepath.Join(path, files[i])
 
-		log.Printf("Writing dashboard to: %s\n", filePath)
-		err = ioutil.WriteFile(filePath, dash, 0644)
+		err = os.MkdirAll(filepath.Dir(filePath), 0755)
 		if err != nil {
 			log.Fatal(err)
 		}
 
+		if exportMirror {
+			manifest.Dashboards[result.UID] = exportManifestEntry{File: files[i], Title: result.Title, Version: dashboardRaw.Meta.Version}
+			changed, err := writeFileIfChanged(filePath, dash)
+			if err != nil {
+				log.Fatal(err)
+			}
+			if changed {
+				log.Printf("Writing dashboard to: %s\n", filePath)
+			}
+		} else {
+			log.Printf("Writing dashboard to: %s\n", filePath)
+			err = ioutil.WriteFile(filePath, dash, 0644)
+			if err != nil {
+				log.Fatal(err)
+			}
+		}
+
+		if exportWithHistory {
+			exportDashboardHistory(c, result.UID, historyPath(filePath))
+		}
+
+		if exportWithLibraryPanels {
+			exportDashboardLibraryPanels(c, filepath.Join(path, "library-panels"), dashboard, libraryPanels)
+		}
+
+	}
+
+	if exportMirror {
+		mirrorExport(c, path, manifest)
+	}
+
+	if !exportNoPublicReport {
+		reportPublicDashboards(c, searchResults)
+	}
+}
+
+// historyPath returns the directory for the versions of the dashboard file
+func historyPath(file string) string {
+	return strings.TrimSuffix(strings.TrimSuffix(file, ".json"), "_dashboard") + "_history"
+}
+
+// exportLayoutData are the fields of the --layout template, all except
+// Title are file friendly
+type exportLayoutData struct {
+	UID       string
+	Title     string
+	Slug      string
+	FirstWord string
+	Folder    string
+	FolderUID string
+	Tags      []string
+	Tag       string
+}
+
+// newExportLayoutData returns the template fields of a dashboard. Titles
+// without any letter or number use the UID as slug, General is the folder
+// of dashboards without folder and untagged the tag of dashboards without tag.
+func newExportLayoutData(uid, title, folderUID, folder string, tags []interface{}) exportLayoutData {
+	data := exportLayoutData{
+		UID:       uid,
+		Title:     title,
+		Slug:      grafana.Slug(title),
+		Folder:    grafana.Slug(folderTitle(folder)),
+		FolderUID: folderUID,
+		Tag:       "untagged",
+	}
+	if data.Slug == "" {
+		data.Slug = grafana.Slug(uid)
+	}
+	data.FirstWord = strings.Split(data.Slug, "_")[0]
+	if data.Folder == "" {
+		data.Folder = folderUID
+	}
+	for _, tag := range tags {
+		if slug := grafana.Slug(fmt.Sprint(tag)); slug != "" {
+			data.Tags = append(data.Tags, slug)
+		}
+	}
+	if len(data.Tags) > 0 {
+		data.Tag = data.Tags[0]
+	}
+	return data
+}
+
+// exportFileName returns the file name of a dashboard relative to --path
+func exportFileName(layout *template.Template, data exportLayoutData) (string, error) {
+	var name bytes.Buffer
+	err := layout.Execute(&name, data)
+	if err != nil {
+		return "", fmt.Errorf("invalid --layout: %s", err)
+	}
+	file := filepath.Clean(name.String())
+	if !belowPath(file) {
+		return "", fmt.Errorf("--layout for dashboard %s returns %q which is not a file below --path", data.UID, name.String())
+	}
+	return file, nil
+}
+
+// belowPath returns true if the relative file name stays below --path
+func belowPath(file string) bool {
+	file = filepath.Clean(file)
+	return !filepath.IsAbs(file) && file != "." && file != ".." && !strings.HasPrefix(file, ".."+string(filepath.Separator))
+}
+
+// checkExportCollisions exits if dashboards would be written to the same
+// file. Names are compared case insensitive for Windows and macOS.
+func checkExportCollisions(files []string, searchResults grafana.SearchResult) {
+	used := map[string][]int{}
+	for i, file := range files {
+		key := strings.ToLower(file)
+		used[key] = append(used[key], i)
+	}
+
+	collisions := [][]string{}
+	for i, file := range files {
+		if len(used[strings.ToLower(file)]) > 1 {
+			collisions = append(collisions, []string{file, searchResults[i].UID, searchResults[i].Title, folderTitle(searchResults[i].FolderTitle)})
+		}
+	}
+	if len(collisions) == 0 {
+		return
+	}
+	sort.Slice(collisions, func(i, j int) bool {
+		return strings.ToLower(collisions[i][0]) < strings.ToLower(collisions[j][0])
+	})
+	log.Printf("Dashboards with the same file name:\n")
+	printTable([]string{"FILE", "UID", "TITLE", "FOLDER"}, collisions)
+	log.Fatal("Nothing exported, add {{.UID}} or {{.Folder}} to --layout to get unique file names")
+}
+
+// reportPublicDashboards warns about exported dashboards which are shared
+// publicly and writes them to <path>/public_dashboards.json for reviews.
+// Grafana versions without public dashboards are skipped.
+func reportPublicDashboards(c *grafana.Client, searchResults grafana.SearchResult) {
+	publicDashboards, err := c.GetPublicDashboards()
+	if err == grafana.ErrPublicDashboardsNotSupported {
+		return
+	}
+	if err != nil {
+		log.Printf("Warning: could not check for public dashboards: %s\n", err)
+		return
+	}
+
+	uids := []string{}
+	for _, result := range searchResults {
+		uids = append(uids, result.UID)
+	}
+	report := publicDashboards.OfDashboards(uids)
+	if len(report) == 0 {
+		return
+	}
+
+	log.Printf("WARNING: %d exported dashboards are shared publicly:\n", len(report))
+	printTable(publicDashboardHeader, publicDashboardRows(report))
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		log.Fatal(err)
+	}
+	filePath := strings.TrimRight(path, "/") + "/public_dashboards.json"
+	log.Printf("Writing public dashboards to: %s\n", filePath)
+	err = ioutil.WriteFile(filePath, data, 0644)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// exportDashboardHistory writes every version of a dashboard as v<version>.json
+func exportDashboardHistory(c *grafana.Client, uid string, historyPath string) {
+	versions, err := c.GetDashboardVersions(uid)
+	if err != nil {
+		log.Fatal(err)
+	}
+	err = os.MkdirAll(historyPath, 0755)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, version := range versions {
+		full, err := c.GetDashboardVersion(uid, version.Version)
+		if err != nil {
+			log.Fatal(err)
+		}
+		data, err := json.MarshalIndent(full, "", "  ")
+		if err != nil {
+			log.Fatal(err)
+		}
+		filePath := fmt.Sprintf("%s/v%d.json", historyPath, version.Version)
+		log.Printf("Writing dashboard version to: %s\n", filePath)
+		err = ioutil.WriteFile(filePath, data, 0644)
+		if err != nil {
+			log.Fatal(err)
+		}
 	}
 }
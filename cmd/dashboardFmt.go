@@ -0,0 +1,101 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"os"
+
+	"github.com/lstuker/grafana-tool/grafana"
+	"github.com/spf13/cobra"
+)
+
+var fmtCheck bool
+
+// dashboardFmtCmd represents the dashboard fmt command
+var dashboardFmtCmd = &cobra.Command{
+	Use:   "fmt FILE...",
+	Short: "Rewrites dashboard files in a stable form for version control",
+	Long: `Rewrites dashboard files in place in a stable form: keys are sorted, the
+volatile fields id, version and iteration are removed, datasource references
+are reduced to type and uid and panels are sorted by their position. Files
+with meta data keep it without the update time and version.
+
+With --check the files are not changed, the ones which are not formatted
+are listed and the command exits with 1, ex: in a CI pipeline.`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		formatDashboards(args)
+	},
+}
+
+func init() {
+	dashboardCmd.AddCommand(dashboardFmtCmd)
+	dashboardFmtCmd.Flags().BoolVar(&fmtCheck, "check", false, "Only list files which are not formatted and exit with 1 if there are any")
+}
+
+func formatDashboards(files []string) {
+	unformatted := 0
+	for _, file := range files {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			log.Fatal(err)
+		}
+		formatted, err := canonicalDashboardFile(data)
+		if err != nil {
+			log.Fatalf("%s: %s", file, err)
+		}
+		if string(formatted) == string(data) {
+			continue
+		}
+
+		unformatted++
+		if fmtCheck {
+			log.Printf("Not formatted: %s\n", file)
+			continue
+		}
+		log.Printf("Formatting: %s\n", file)
+		err = ioutil.WriteFile(file, formatted, 0644)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if fmtCheck && unformatted > 0 {
+		os.Exit(1)
+	}
+}
+
+// canonicalDashboardFile returns the canonical form of a dashboard file,
+// which is a dashboard or a dashboard with meta data
+func canonicalDashboardFile(data []byte) ([]byte, error) {
+	var document map[string]interface{}
+	err := json.Unmarshal(data, &document)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := document["dashboard"].(map[string]interface{}); ok {
+		var full grafana.DashboardRawJSON
+		err = json.Unmarshal(data, &full)
+		if err != nil {
+			return nil, err
+		}
+		return grafana.CanonicalJSON(full.Canonical())
+	}
+	return grafana.CanonicalJSON(grafana.Canonicalize(document))
+}
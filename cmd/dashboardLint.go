@@ -0,0 +1,220 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+
+	"github.com/lstuker/grafana-tool/grafana"
+	"github.com/spf13/cobra"
+	yaml "gopkg.in/yaml.v2"
+)
+
+var lintSelector dashboardSelector
+var lintRulesFile string
+var lintSarif bool
+var lintListRules bool
+
+// defaultLintRulesFile is read if it exists and --rules is not given
+const defaultLintRulesFile = ".grafana-lint.yaml"
+
+// lintResult are the problems of a dashboard, Source is the file or UID
+type lintResult struct {
+	Source   string                `json:"source"`
+	Title    string                `json:"title"`
+	Problems []grafana.LintProblem `json:"problems"`
+}
+
+// dashboardLintCmd represents the dashboard lint command
+var dashboardLintCmd = &cobra.Command{
+	Use:   "lint [FILE...]",
+	Short: "Checks dashboards against conventions",
+	Long: `Checks dashboard files or dashboards on Grafana, selected by UID, title,
+folder or tag, against the built-in rules. Rules are enabled, disabled or get
+another severity in a rules file, .grafana-lint.yaml by default:
+
+  rules:
+    panel-description:
+      enabled: false
+    panel-unit:
+      severity: error
+
+A dashboard with the tag lint-ignore is skipped, the tag lint-ignore:<rule>
+skips a single rule. The problems are written as text, with --output as json
+or yaml and with --sarif as SARIF for CI annotations. Exits with 1 if a
+problem with severity error is found.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		lintDashboards(cmd, args)
+	},
+}
+
+func init() {
+	dashboardCmd.AddCommand(dashboardLintCmd)
+	addDashboardSelectFlags(dashboardLintCmd, &lintSelector)
+	dashboardLintCmd.Flags().StringVar(&lintRulesFile, "rules", "", "Rules file, default "+defaultLintRulesFile+" if it exists")
+	dashboardLintCmd.Flags().BoolVar(&lintSarif, "sarif", false, "Write the result as SARIF for CI annotations")
+	dashboardLintCmd.Flags().BoolVar(&lintListRules, "list-rules", false, "List the rules with the rules file applied")
+}
+
+func lintDashboards(cmd *cobra.Command, files []string) {
+	rules := readLintRules()
+
+	if lintListRules {
+		rows := [][]string{}
+		for _, rule := range rules {
+			rows = append(rows, []string{rule.ID, rule.Severity, rule.Description})
+		}
+		printList([]string{"RULE", "SEVERITY", "DESCRIPTION"}, rows, rules, 0)
+		return
+	}
+	if len(files) == 0 && lintSelector.empty() {
+		log.Fatal("Give dashboard files or select dashboards with --uid, --title, --title-regex, --folder or --tag")
+	}
+
+	results := []lintResult{}
+	for _, file := range files {
+		raw, err := readDashboardFile(file)
+		if err != nil {
+			log.Fatal(err)
+		}
+		dashboard, err := typedDashboard(raw)
+		if err != nil {
+			log.Fatalf("%s: %s", file, err)
+		}
+		results = append(results, lintResult{Source: file, Title: dashboard.Title, Problems: dashboard.Lint(rules)})
+	}
+	if !lintSelector.empty() {
+		c := newClient()
+		for _, result := range selectDashboards(c, lintSelector) {
+			raw, err := c.GetDashboardRawByUID(result.UID)
+			if err != nil {
+				log.Fatal(err)
+			}
+			dashboard, err := typedDashboard(raw.Dashboard)
+			if err != nil {
+				log.Fatalf("%s: %s", result.UID, err)
+			}
+			results = append(results, lintResult{Source: result.UID, Title: dashboard.Title, Problems: dashboard.Lint(rules)})
+		}
+	}
+
+	switch {
+	case lintSarif:
+		outputFormat = "json"
+		printRecords(sarifLog(rules, results))
+	case outputFormat == "json" || outputFormat == "yaml":
+		printRecords(results)
+	default:
+		for _, result := range results {
+			for _, problem := range result.Problems {
+				location := result.Source
+				if problem.PanelID != 0 {
+					location = fmt.Sprintf("%s: panel %d %q", location, problem.PanelID, problem.Panel)
+				}
+				fmt.Printf("%s: %s %s: %s\n", location, problem.Severity, problem.Rule, problem.Message)
+			}
+		}
+	}
+
+	for _, result := range results {
+		for _, problem := range result.Problems {
+			if problem.Severity == grafana.LintError {
+				os.Exit(1)
+			}
+		}
+	}
+}
+
+// readLintRules returns the built-in rules with the rules file applied
+func readLintRules() []grafana.LintRule {
+	file := lintRulesFile
+	if file == "" {
+		if !isFile(defaultLintRulesFile) {
+			rules, _ := grafana.LintRules(grafana.LintConfig{})
+			return rules
+		}
+		file = defaultLintRulesFile
+	}
+
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var config grafana.LintConfig
+	err = yaml.Unmarshal(data, &config)
+	if err != nil {
+		log.Fatalf("%s: %s", file, err)
+	}
+	rules, err := grafana.LintRules(config)
+	if err != nil {
+		log.Fatalf("%s: %s", file, err)
+	}
+	return rules
+}
+
+// sarifLog returns the lint results in the Static Analysis Results
+// Interchange Format 2.1.0, which CI systems show as annotations
+func sarifLog(rules []grafana.LintRule, results []lintResult) map[string]interface{} {
+	levels := map[string]string{grafana.LintError: "error", grafana.LintWarning: "warning", grafana.LintInfo: "note"}
+
+	sarifRules := []map[string]interface{}{}
+	for _, rule := range rules {
+		sarifRules = append(sarifRules, map[string]interface{}{
+			"id":                   rule.ID,
+			"shortDescription":     map[string]string{"text": rule.Description},
+			"defaultConfiguration": map[string]string{"level": levels[rule.Severity]},
+		})
+	}
+
+	sarifResults := []map[string]interface{}{}
+	for _, result := range results {
+		for _, problem := range result.Problems {
+			logical := map[string]interface{}{"name": result.Title, "kind": "dashboard"}
+			if problem.PanelID != 0 {
+				logical = map[string]interface{}{"name": problem.Panel, "fullyQualifiedName": fmt.Sprintf("%s/panel/%d", result.Title, problem.PanelID), "kind": "panel"}
+			}
+			sarifResults = append(sarifResults, map[string]interface{}{
+				"ruleId":  problem.Rule,
+				"level":   levels[problem.Severity],
+				"message": map[string]string{"text": problem.Message},
+				"locations": []map[string]interface{}{{
+					"physicalLocation": map[string]interface{}{
+						"artifactLocation": map[string]string{"uri": result.Source},
+					},
+					"logicalLocations": []map[string]interface{}{logical},
+				}},
+			})
+		}
+	}
+
+	return map[string]interface{}{
+		"$schema": "https://json.schemastore.org/sarif-2.1.0.json",
+		"version": "2.1.0",
+		"runs": []map[string]interface{}{{
+			"tool": map[string]interface{}{
+				"driver": map[string]interface{}{
+					"name":           "grafana-tool",
+					"version":        version,
+					"informationUri": "https://github.com/lstuker/grafana-tool",
+					"rules":          sarifRules,
+				},
+			},
+			"results": sarifResults,
+		}},
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"log"
+
+	"github.com/lstuker/grafana-tool/grafana"
+	"github.com/spf13/cobra"
+)
+
+var migrateTo int
+var migrateDryRun bool
+var migrateConvertPanels bool
+
+// dashboardMigrateCmd represents the dashboard migrate command
+var dashboardMigrateCmd = &cobra.Command{
+	Use:   "migrate FILE...",
+	Short: "Upgrades dashboard files to a newer schema version",
+	Long: `Upgrades dashboard files in place step by step to a newer schema version,
+so the migrated JSON can be reviewed and committed instead of being migrated
+by Grafana in the browser. The steps are:
+
+  16  rows to row panels with grid positions
+  33  panel and query datasource names to {type,uid} references
+  36  variable and annotation datasource names to {type,uid} references
+
+A dashboard is migrated up to the version before the first one without a
+step and gets that schemaVersion, ex: 16 for a dashboard of version 14, so
+Grafana still runs its own migrations of the later versions.
+
+Graph panels are kept unless --convert-panels is given, which converts them
+to timeseries panels like dashboard convert-panels.
+
+Datasource names are resolved with the datasources of the configured
+Grafana, without --grafana-url they are referenced by UID only. The legacy
+name default becomes the default datasource of Grafana. Changes which
+need a review are listed. The key order and indentation of the files are kept,
+so only the migrated parts show in the diff.
+
+ex: grafana-tool dashboard migrate --to 36 linux/*.json`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		migrateDashboards(args)
+	},
+}
+
+func init() {
+	dashboardCmd.AddCommand(dashboardMigrateCmd)
+	dashboardMigrateCmd.Flags().IntVar(&migrateTo, "to", grafana.LatestSchemaVersion, "Target schema version")
+	dashboardMigrateCmd.Flags().BoolVar(&migrateConvertPanels, "convert-panels", false, "Convert graph panels to timeseries panels")
+	dashboardMigrateCmd.Flags().BoolVar(&migrateDryRun, "dry-run", false, "Only show the notes, do not write the files")
+}
+
+func migrateDashboards(files []string) {
+	var datasources grafana.DatasourceListJSON
+	if grafanaURL != "" {
+		var err error
+		datasources, err = newClient().GetDatasources()
+		if err != nil {
+			log.Fatal(err)
+		}
+	} else {
+		log.Println("Warning: no --grafana-url, datasource names are referenced by UID only")
+	}
+
+	for _, file := range files {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			log.Fatal(err)
+		}
+		var document map[string]interface{}
+		err = json.Unmarshal(data, &document)
+		if err != nil {
+			log.Fatalf("%s: %s", file, err)
+		}
+		dashboard := document
+		if inner, ok := document["dashboard"].(map[string]interface{}); ok {
+			dashboard = inner
+		}
+
+		notes, err := grafana.MigrateDashboard(dashboard, migrateTo, datasources, migrateConvertPanels)
+		if err != nil {
+			log.Fatalf("%s: %s", file, err)
+		}
+		for _, note := range notes {
+			log.Printf("%s: %s\n", file, note)
+		}
+		if migrateDryRun {
+			continue
+		}
+
+		err = writeDashboardFile(file, data, document)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+}
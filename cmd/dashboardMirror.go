@@ -0,0 +1,182 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/lstuker/grafana-tool/grafana"
+)
+
+// exportManifestFile is the manifest of an export with --mirror, relative to --path
+const exportManifestFile = ".grafana-tool-manifest.json"
+
+// exportStaleDir is the directory stale files are moved to, relative to --path
+const exportStaleDir = ".stale"
+
+// exportManifest lists the dashboards of the last export with --mirror by UID
+type exportManifest struct {
+	Dashboards map[string]exportManifestEntry `json:"dashboards"`
+}
+
+// exportManifestEntry is an exported dashboard, File is relative to --path
+type exportManifestEntry struct {
+	File    string `json:"file"`
+	Title   string `json:"title"`
+	Version int    `json:"version"`
+}
+
+// mirrorExport handles the files of the last export which are not part of
+// the new one and writes the new manifest. Only files listed in the last
+// manifest are touched, other files in --path are kept. Dashboards of the
+// last export which still exist on Grafana but are not part of this one,
+// ex: with another --folder, are kept in the manifest.
+func mirrorExport(c *grafana.Client, exportPath string, manifest exportManifest) {
+	manifestPath := filepath.Join(exportPath, exportManifestFile)
+	last, err := readExportManifest(manifestPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	current := map[string]bool{}
+	for _, entry := range manifest.Dashboards {
+		current[entry.File] = true
+	}
+
+	uids := []string{}
+	for uid := range last.Dashboards {
+		uids = append(uids, uid)
+	}
+	sort.Strings(uids)
+
+	stale := []string{}
+	for _, uid := range uids {
+		entry := last.Dashboards[uid]
+		if current[entry.File] {
+			continue
+		}
+		if _, ok := manifest.Dashboards[uid]; ok {
+			log.Printf("Dashboard %s (%s) was renamed, %s is stale\n", entry.Title, uid, entry.File)
+			stale = append(stale, entry.File, historyPath(entry.File))
+			continue
+		}
+
+		exists, err := c.DashboardExists(uid)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if exists {
+			manifest.Dashboards[uid] = entry
+			continue
+		}
+		log.Printf("Dashboard %s (%s) was deleted, %s is stale\n", entry.Title, uid, entry.File)
+		stale = append(stale, entry.File, historyPath(entry.File))
+	}
+
+	for _, file := range stale {
+		err = removeStaleFile(exportPath, file)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		log.Fatal(err)
+	}
+	_, err = writeFileIfChanged(manifestPath, data)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// readExportManifest reads the manifest, which is empty before the first
+// export with --mirror
+func readExportManifest(file string) (exportManifest, error) {
+	manifest := exportManifest{Dashboards: map[string]exportManifestEntry{}}
+	data, err := ioutil.ReadFile(file)
+	if os.IsNotExist(err) {
+		return manifest, nil
+	}
+	if err != nil {
+		return manifest, err
+	}
+	err = json.Unmarshal(data, &manifest)
+	if err != nil {
+		return manifest, fmt.Errorf("%s: %s", file, err)
+	}
+	for uid, entry := range manifest.Dashboards {
+		if !belowPath(entry.File) {
+			return manifest, fmt.Errorf("%s: file %q of dashboard %s is not below --path", file, entry.File, uid)
+		}
+	}
+	return manifest, nil
+}
+
+// removeStaleFile deletes the file or directory or moves it with its
+// relative path to the stale directory, depending on --stale
+func removeStaleFile(exportPath string, file string) error {
+	source := filepath.Join(exportPath, file)
+	if _, err := os.Stat(source); os.IsNotExist(err) {
+		return nil
+	}
+
+	defer removeEmptyDirs(exportPath, filepath.Dir(source))
+
+	if exportStale == "delete" {
+		log.Printf("Deleting stale file: %s\n", source)
+		return os.RemoveAll(source)
+	}
+
+	target := filepath.Join(exportPath, exportStaleDir, file)
+	log.Printf("Moving stale file %s to %s\n", source, target)
+	err := os.MkdirAll(filepath.Dir(target), 0755)
+	if err != nil {
+		return err
+	}
+	err = os.RemoveAll(target)
+	if err != nil {
+		return err
+	}
+	return os.Rename(source, target)
+}
+
+// removeEmptyDirs removes dir and its parents below exportPath as long as
+// they are empty
+func removeEmptyDirs(exportPath string, dir string) {
+	root := filepath.Clean(exportPath)
+	for dir = filepath.Clean(dir); dir != root && len(dir) > len(root); dir = filepath.Dir(dir) {
+		if os.Remove(dir) != nil {
+			return
+		}
+	}
+}
+
+// writeFileIfChanged writes data only if the file does not have this
+// content already, so unchanged files keep their modification time
+func writeFileIfChanged(file string, data []byte) (bool, error) {
+	existing, err := ioutil.ReadFile(file)
+	if err == nil && bytes.Equal(existing, data) {
+		return false, nil
+	}
+	return true, ioutil.WriteFile(file, data, 0644)
+}
@@ -0,0 +1,112 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/lstuker/grafana-tool/grafana"
+	"github.com/spf13/cobra"
+)
+
+var moveSelector dashboardSelector
+var moveTo string
+var moveDryRun bool
+
+// dashboardMoveCmd represents the dashboard move command
+var dashboardMoveCmd = &cobra.Command{
+	Use:   "move",
+	Short: "Moves dashboards to another folder",
+	Long: `Moves the dashboards selected by UID, title, title regex, tag or source
+folder to the folder given with --to. Each dashboard is saved again with the
+same ID and UID, so its version history and permissions are kept.
+
+ex: grafana-tool dashboard move --folder Test --title-regex "^Linux" --to Linux`,
+	Run: func(cmd *cobra.Command, args []string) {
+		moveDashboards()
+	},
+}
+
+func init() {
+	dashboardCmd.AddCommand(dashboardMoveCmd)
+	addDashboardSelectFlags(dashboardMoveCmd, &moveSelector)
+	dashboardMoveCmd.Flags().StringVar(&moveTo, "to", "", "Name of the target folder, General for no folder (required)")
+	dashboardMoveCmd.MarkFlagRequired("to")
+	dashboardMoveCmd.Flags().BoolVar(&moveDryRun, "dry-run", false, "Only show the dashboards which would be moved")
+}
+
+func moveDashboards() {
+	if moveSelector.empty() {
+		log.Fatal("Select the dashboards with --uid, --title, --title-regex, --folder or --tag")
+	}
+
+	c := newClient()
+	target := findFolder(c, moveTo)
+	selected := selectDashboards(c, moveSelector)
+
+	moved := 0
+	failures := [][]string{}
+	for _, result := range selected {
+		if result.FolderID == target.ID {
+			continue
+		}
+		if moveDryRun {
+			log.Printf("Would move dashboard %s (%s) from %s to %s\n", result.Title, result.UID, folderTitle(result.FolderTitle), target.Title)
+			continue
+		}
+
+		err := moveDashboard(c, result.UID, target)
+		if err != nil {
+			failures = append(failures, []string{result.UID, result.Title, err.Error()})
+			continue
+		}
+		log.Printf("Moved dashboard %s (%s) from %s to %s\n", result.Title, result.UID, folderTitle(result.FolderTitle), target.Title)
+		moved++
+	}
+
+	if moveDryRun {
+		return
+	}
+	log.Printf("Moved %d dashboards to %s\n", moved, target.Title)
+	if len(failures) > 0 {
+		log.Printf("%d dashboards could not be moved:\n", len(failures))
+		printTable([]string{"UID", "TITLE", "ERROR"}, failures)
+		log.Fatal("Move incomplete")
+	}
+}
+
+// moveDashboard saves the dashboard unchanged in the target folder
+func moveDashboard(c *grafana.Client, uid string, target grafana.FolderJSON) error {
+	dashboard, err := c.GetDashboardRawByUID(uid)
+	if err != nil {
+		return err
+	}
+	_, err = c.SaveDashboard(grafana.DashboardSaveJSON{
+		Dashboard: dashboard.Dashboard,
+		FolderID:  target.ID,
+		FolderUID: target.UID,
+		Message:   fmt.Sprintf("Moved from %s to %s", folderTitle(dashboard.Meta.FolderTitle), target.Title),
+	})
+	return err
+}
+
+// folderTitle returns the title of the folder, General for no folder
+func folderTitle(title string) string {
+	if title == "" {
+		return "General"
+	}
+	return title
+}
@@ -0,0 +1,62 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"log"
+	"regexp"
+
+	"github.com/lstuker/grafana-tool/grafana"
+	"github.com/spf13/cobra"
+)
+
+// dashboardQueryCmd represents the dashboard query command
+var dashboardQueryCmd = &cobra.Command{
+	Use:   "query",
+	Short: "Search and rewrite dashboard queries",
+	Long: `Search and rewrite the queries of dashboard panels in the language of their
+datasource: expr of Prometheus and Loki, rawSql of MySQL and Postgres, query
+of Elasticsearch and InfluxDB, the InfluxQL or CloudWatch metric built by the
+query editor and the target of Graphite.`,
+}
+
+func init() {
+	dashboardCmd.AddCommand(dashboardQueryCmd)
+}
+
+// queryRegexp compiles the regular expression given as argument
+func queryRegexp(expr string) *regexp.Regexp {
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		log.Fatalf("Invalid regular expression: %s", err)
+	}
+	return re
+}
+
+// queryDatasources returns the datasources to resolve datasource names to
+// types, without --grafana-url the types are guessed from the queries
+func queryDatasources(c *grafana.Client) grafana.DatasourceListJSON {
+	if c == nil && grafanaURL == "" {
+		return nil
+	}
+	if c == nil {
+		c = newClient()
+	}
+	datasources, err := c.GetDatasources()
+	if err != nil {
+		log.Fatal(err)
+	}
+	return datasources
+}
@@ -0,0 +1,123 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"log"
+	"os"
+	"strconv"
+
+	"github.com/lstuker/grafana-tool/grafana"
+	"github.com/spf13/cobra"
+)
+
+var queryGrepSelector dashboardSelector
+var queryGrepType string
+
+// dashboardQueryGrepCmd represents the dashboard query grep command
+var dashboardQueryGrepCmd = &cobra.Command{
+	Use:   "grep REGEX [FILE...]",
+	Short: "Lists the dashboard queries matching a regular expression",
+	Long: `Lists every dashboard, panel and query whose query text matches the regular
+expression, including the panels of collapsed rows. The dashboards are read
+from the files or from Grafana, selected by UID, title, title regex, folder or
+tag, without files and selection all dashboards of Grafana are searched.
+Exits with 1 if no query matches.
+
+ex: grafana-tool dashboard query grep 'node_cpu\b' --type prometheus
+ex: grafana-tool dashboard query grep 'FROM "cpu"' linux/*.json`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		grepQueries(args[0], args[1:])
+	},
+}
+
+// queryMatch is a query found by dashboard query grep
+type queryMatch struct {
+	Dashboard      string `json:"dashboard"`
+	Title          string `json:"title"`
+	PanelID        int    `json:"panelId"`
+	Panel          string `json:"panel"`
+	RefID          string `json:"refId"`
+	DatasourceType string `json:"datasourceType"`
+	Field          string `json:"field"`
+	Query          string `json:"query"`
+}
+
+func init() {
+	dashboardQueryCmd.AddCommand(dashboardQueryGrepCmd)
+	addDashboardSelectFlags(dashboardQueryGrepCmd, &queryGrepSelector)
+	dashboardQueryGrepCmd.Flags().StringVar(&queryGrepType, "type", "", "Search only queries of this datasource type, ex: prometheus")
+}
+
+func grepQueries(expr string, files []string) {
+	re := queryRegexp(expr)
+	matches := []queryMatch{}
+	grep := func(source string, raw map[string]interface{}, datasources grafana.DatasourceListJSON) {
+		dashboard, err := typedDashboard(raw)
+		if err != nil {
+			log.Fatalf("%s: %s", source, err)
+		}
+		for _, query := range dashboard.Queries(datasources) {
+			if queryGrepType != "" && query.DatasourceType != queryGrepType {
+				continue
+			}
+			if !re.MatchString(query.Text) {
+				continue
+			}
+			matches = append(matches, queryMatch{
+				Dashboard:      source,
+				Title:          dashboard.Title,
+				PanelID:        query.Panel.ID,
+				Panel:          query.Panel.Title,
+				RefID:          query.RefID,
+				DatasourceType: query.DatasourceType,
+				Field:          query.Field,
+				Query:          query.Text,
+			})
+		}
+	}
+
+	if len(files) > 0 {
+		datasources := queryDatasources(nil)
+		for _, file := range files {
+			raw, err := readDashboardFile(file)
+			if err != nil {
+				log.Fatal(err)
+			}
+			grep(file, raw, datasources)
+		}
+	}
+	if len(files) == 0 || !queryGrepSelector.empty() {
+		c := newClient()
+		datasources := queryDatasources(c)
+		for _, result := range selectDashboards(c, queryGrepSelector) {
+			raw, err := c.GetDashboardRawByUID(result.UID)
+			if err != nil {
+				log.Fatal(err)
+			}
+			grep(result.UID, raw.Dashboard, datasources)
+		}
+	}
+
+	rows := [][]string{}
+	for _, match := range matches {
+		rows = append(rows, []string{match.Dashboard, match.Title, strconv.Itoa(match.PanelID), match.Panel, match.RefID, match.DatasourceType, match.Query})
+	}
+	printList([]string{"DASHBOARD", "TITLE", "PANEL ID", "PANEL", "REF", "TYPE", "QUERY"}, rows, matches, 0)
+	if len(matches) == 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,190 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"regexp"
+
+	"github.com/lstuker/grafana-tool/grafana"
+	"github.com/spf13/cobra"
+)
+
+var queryReplaceSelector dashboardSelector
+var queryReplaceType string
+var queryReplaceDryRun bool
+var queryReplaceMessage string
+var queryReplaceYes bool
+
+// dashboardQueryReplaceCmd represents the dashboard query replace command
+var dashboardQueryReplaceCmd = &cobra.Command{
+	Use:   "replace REGEX REPLACEMENT [FILE...]",
+	Short: "Rewrites the dashboard queries matching a regular expression",
+	Long: `Replaces the matches of the regular expression in the query texts, the
+replacement can use the groups of the expression like $1. Queries built by
+a query editor are rewritten in their measurement, field and metric names.
+
+Dashboard files are changed in place, keeping their key order. Without files
+the dashboards selected by UID, title, title regex, folder or tag, or all
+dashboards, are saved on Grafana as a new version with the --message, older
+versions can be restored with dashboard restore. The changed queries are shown
+as diff, --dry-run only shows the diff. Saving on Grafana has to be confirmed
+unless --yes is given.
+
+ex: grafana-tool dashboard query replace '\bnode_cpu\b' node_cpu_seconds_total --type prometheus --dry-run
+ex: grafana-tool dashboard query replace 'host=~"(.*)"' 'instance=~"$1"' linux/*.json`,
+	Args: cobra.MinimumNArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		replaceQueries(args[0], args[1], args[2:])
+	},
+}
+
+func init() {
+	dashboardQueryCmd.AddCommand(dashboardQueryReplaceCmd)
+	addDashboardSelectFlags(dashboardQueryReplaceCmd, &queryReplaceSelector)
+	dashboardQueryReplaceCmd.Flags().StringVar(&queryReplaceType, "type", "", "Rewrite only queries of this datasource type, ex: prometheus")
+	dashboardQueryReplaceCmd.Flags().BoolVar(&queryReplaceDryRun, "dry-run", false, "Only show the diff of the queries")
+	dashboardQueryReplaceCmd.Flags().BoolVarP(&queryReplaceYes, "yes", "y", false, "Save on Grafana without confirmation, for scripts")
+	dashboardQueryReplaceCmd.Flags().StringVarP(&queryReplaceMessage, "message", "m", "", "Message of the saved dashboard versions, default describes the replacement")
+}
+
+func replaceQueries(expr string, replacement string, files []string) {
+	re := queryRegexp(expr)
+	if len(files) > 0 && !queryReplaceSelector.empty() {
+		log.Fatal("Give dashboard files or select dashboards on Grafana, not both")
+	}
+	if queryReplaceMessage == "" {
+		queryReplaceMessage = fmt.Sprintf("Replaced queries matching %s with %s", expr, replacement)
+	}
+
+	changed := 0
+	if len(files) > 0 {
+		datasources := queryDatasources(nil)
+		for _, file := range files {
+			if replaceFileQueries(file, re, replacement, datasources) {
+				changed++
+			}
+		}
+	} else {
+		changed = replaceGrafanaQueries(re, replacement)
+	}
+
+	if queryReplaceDryRun {
+		log.Printf("%d dashboards would be changed\n", changed)
+		return
+	}
+	log.Printf("Changed %d dashboards\n", changed)
+}
+
+// replaceFileQueries rewrites the queries of a dashboard file in place and
+// returns true if a query changed
+func replaceFileQueries(file string, re *regexp.Regexp, replacement string, datasources grafana.DatasourceListJSON) bool {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var document map[string]interface{}
+	err = json.Unmarshal(data, &document)
+	if err != nil {
+		log.Fatalf("%s: %s", file, err)
+	}
+	dashboard := document
+	if inner, ok := document["dashboard"].(map[string]interface{}); ok {
+		dashboard = inner
+	}
+
+	changes := grafana.ReplaceQueries(dashboard, re, replacement, queryReplaceType, datasources)
+	printQueryChanges(file, changes)
+	if queryReplaceDryRun || len(changes) == 0 {
+		return len(changes) > 0
+	}
+
+	err = writeDashboardFile(file, data, document)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return true
+}
+
+// replaceGrafanaQueries rewrites the queries of the selected dashboards and
+// saves them on Grafana after a confirmation, it returns the number of
+// changed dashboards
+func replaceGrafanaQueries(re *regexp.Regexp, replacement string) int {
+	c := newClient()
+	datasources := queryDatasources(c)
+
+	changed := []grafana.DashboardRawJSON{}
+	for _, result := range selectDashboards(c, queryReplaceSelector) {
+		raw, err := c.GetDashboardRawByUID(result.UID)
+		if err != nil {
+			log.Fatal(err)
+		}
+		changes := grafana.ReplaceQueries(raw.Dashboard, re, replacement, queryReplaceType, datasources)
+		printQueryChanges(result.UID, changes)
+		if len(changes) > 0 {
+			changed = append(changed, raw)
+		}
+	}
+
+	if queryReplaceDryRun || len(changed) == 0 {
+		return len(changed)
+	}
+	if !queryReplaceYes && !confirm(fmt.Sprintf("Save %d dashboards on Grafana?", len(changed))) {
+		log.Println("Aborted")
+		return 0
+	}
+
+	saved := 0
+	failures := [][]string{}
+	for _, raw := range changed {
+		uid, _ := raw.Dashboard["uid"].(string)
+		title, _ := raw.Dashboard["title"].(string)
+		_, err := c.SaveDashboard(grafana.DashboardSaveJSON{
+			Dashboard: raw.Dashboard,
+			FolderID:  raw.Meta.FolderID,
+			FolderUID: raw.Meta.FolderUID,
+			Message:   queryReplaceMessage,
+		})
+		if err != nil {
+			failures = append(failures, []string{uid, title, err.Error()})
+			continue
+		}
+		log.Printf("Saved dashboard %s (%s)\n", title, uid)
+		saved++
+	}
+
+	if len(failures) > 0 {
+		log.Printf("%d dashboards could not be saved:\n", len(failures))
+		printTable([]string{"UID", "TITLE", "ERROR"}, failures)
+		log.Fatal("Replace incomplete")
+	}
+	return saved
+}
+
+// printQueryChanges prints the changed queries of a dashboard as diff
+func printQueryChanges(source string, changes []grafana.QueryChange) {
+	for _, change := range changes {
+		fmt.Printf("%s (%s)\n", queryLocation(source, change.PanelID, change.Panel, change.RefID), change.DatasourceType)
+		fmt.Printf("- %s\n+ %s\n", change.Old, change.New)
+	}
+}
+
+// queryLocation describes a query for messages, ex: linux.json: panel 2 "CPU" query A
+func queryLocation(source string, panelID int, panel string, refID string) string {
+	return fmt.Sprintf("%s: panel %d %q query %s", source, panelID, panel, refID)
+}
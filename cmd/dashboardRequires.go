@@ -0,0 +1,131 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/lstuker/grafana-tool/grafana"
+	"github.com/spf13/cobra"
+)
+
+var requiresFiles []string
+
+// dashboardRequiresCmd represents the dashboard requires command
+var dashboardRequiresCmd = &cobra.Command{
+	Use:   "requires [UID...]",
+	Short: "Lists the plugins dashboards need and flags the missing ones",
+	Long: `Lists the panel and datasource plugins the dashboards need, including the
+panels in collapsed rows, and flags the plugins not installed on Grafana.
+The dashboards are read from Grafana by UID or from files written by
+dashboard export, without UID and file all dashboards of Grafana are checked.
+Exits with 1 if a plugin is missing.
+
+ex: grafana-tool dashboard requires --file linux/linux_memory_dashboard.json --grafana-url http://new.bar:3000`,
+	Run: func(cmd *cobra.Command, args []string) {
+		dashboardRequires(args)
+	},
+}
+
+func init() {
+	dashboardCmd.AddCommand(dashboardRequiresCmd)
+	dashboardRequiresCmd.Flags().StringSliceVar(&requiresFiles, "file", nil, "Dashboard file, can be repeated")
+}
+
+func dashboardRequires(uids []string) {
+	c := newClient()
+	dashboards := map[string]grafana.DashboardJSON{}
+
+	for _, file := range requiresFiles {
+		raw, err := readDashboardFile(file)
+		if err != nil {
+			log.Fatal(err)
+		}
+		dashboards[file], err = typedDashboard(raw)
+		if err != nil {
+			log.Fatalf("%s: %s", file, err)
+		}
+	}
+
+	if len(uids) == 0 && len(requiresFiles) == 0 {
+		searchResults, err := c.SearchDashboard("", "", "dash-db")
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, result := range searchResults {
+			uids = append(uids, result.UID)
+		}
+	}
+	for _, uid := range uids {
+		raw, err := c.GetDashboardRawByUID(uid)
+		if err != nil {
+			log.Fatal(err)
+		}
+		dashboards[uid], err = typedDashboard(raw.Dashboard)
+		if err != nil {
+			log.Fatalf("%s: %s", uid, err)
+		}
+	}
+
+	datasources, err := c.GetDatasources()
+	if err != nil {
+		log.Fatal(err)
+	}
+	plugins, err := c.GetPlugins("")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	missing := 0
+	rows := [][]string{}
+	for source, dashboard := range dashboards {
+		required, unresolved := dashboard.RequiredPlugins(datasources)
+		for _, requirement := range required {
+			status := "installed"
+			if !plugins.Installed(requirement.ID) {
+				status = "missing"
+				missing++
+			}
+			rows = append(rows, []string{source, dashboard.Title, requirement.Type, requirement.ID, status})
+		}
+		for _, name := range unresolved {
+			rows = append(rows, []string{source, dashboard.Title, "datasource", name, "unresolved"})
+		}
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		return strings.Join(rows[i], "\t") < strings.Join(rows[j], "\t")
+	})
+	printList([]string{"DASHBOARD", "TITLE", "TYPE", "PLUGIN", "STATUS"}, rows, nil, 3)
+
+	if missing > 0 {
+		log.Printf("%d required plugins are not installed\n", missing)
+		os.Exit(1)
+	}
+}
+
+// typedDashboard converts a generic dashboard to DashboardJSON
+func typedDashboard(raw map[string]interface{}) (grafana.DashboardJSON, error) {
+	var dashboard grafana.DashboardJSON
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return dashboard, err
+	}
+	err = json.Unmarshal(data, &dashboard)
+	return dashboard, err
+}
@@ -0,0 +1,48 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"log"
+
+	"github.com/spf13/cobra"
+)
+
+var restoreVersion int
+
+// dashboardRestoreCmd represents the dashboard restore command
+var dashboardRestoreCmd = &cobra.Command{
+	Use:   "restore UID",
+	Short: "Restores a dashboard to an older version",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		restoreDashboard(args[0])
+	},
+}
+
+func init() {
+	dashboardCmd.AddCommand(dashboardRestoreCmd)
+	dashboardRestoreCmd.Flags().IntVar(&restoreVersion, "version", 0, "Version to restore (required)")
+	dashboardRestoreCmd.MarkFlagRequired("version")
+}
+
+func restoreDashboard(uid string) {
+	c := newClient()
+	result, err := c.RestoreDashboardVersion(uid, restoreVersion)
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("Restored dashboard %s to version %d as new version %d\n", uid, restoreVersion, result.Version)
+}
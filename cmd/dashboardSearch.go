@@ -0,0 +1,98 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/lstuker/grafana-tool/grafana"
+	"github.com/spf13/cobra"
+)
+
+var searchQuery string
+var searchTags []string
+var searchFolders []string
+var searchStarred bool
+var searchType string
+
+// searchTypes maps the --type values to the Grafana search types
+var searchTypes = map[string]string{
+	"":          "",
+	"dashboard": "dash-db",
+	"folder":    "dash-folder",
+}
+
+// dashboardSearchCmd represents the dashboard search command
+var dashboardSearchCmd = &cobra.Command{
+	Use:   "search",
+	Short: "Searches dashboards and folders",
+	Long: `Searches dashboards and folders by title, tag, folder and starred. Use the
+global --output flag to get the result as json, yaml, csv or only the UIDs.
+
+ex: grafana-tool dashboard search --tag linux --output name`,
+	Run: func(cmd *cobra.Command, args []string) {
+		searchDashboards()
+	},
+}
+
+func init() {
+	dashboardCmd.AddCommand(dashboardSearchCmd)
+	dashboardSearchCmd.Flags().StringVarP(&searchQuery, "query", "q", "", "Part of the title")
+	dashboardSearchCmd.Flags().StringSliceVar(&searchTags, "tag", nil, "Tag, can be repeated, all tags have to match")
+	dashboardSearchCmd.Flags().StringSliceVarP(&searchFolders, "folder", "f", nil, "Folder name, General for dashboards without folder, can be repeated")
+	dashboardSearchCmd.Flags().BoolVar(&searchStarred, "starred", false, "Only dashboards starred by the user")
+	dashboardSearchCmd.Flags().StringVar(&searchType, "type", "", "Only results of type: dashboard or folder")
+}
+
+func searchDashboards() {
+	queryType, ok := searchTypes[searchType]
+	if !ok {
+		log.Fatalf("Invalid type %s, use dashboard or folder", searchType)
+	}
+
+	c := newClient()
+	query := grafana.SearchQuery{
+		Query:   searchQuery,
+		Tags:    searchTags,
+		Type:    queryType,
+		Starred: searchStarred,
+	}
+	for _, folder := range searchFolders {
+		query.FolderIDs = append(query.FolderIDs, findFolder(c, folder).ID)
+	}
+
+	results, err := c.Search(query)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	rows := [][]string{}
+	for _, result := range results {
+		tags := []string{}
+		for _, tag := range result.Tags {
+			tags = append(tags, fmt.Sprint(tag))
+		}
+		resultType := "dashboard"
+		folder := folderTitle(result.FolderTitle)
+		if result.Type == "dash-folder" {
+			resultType = "folder"
+			folder = ""
+		}
+		rows = append(rows, []string{result.UID, result.Title, resultType, folder, strings.Join(tags, ",")})
+	}
+	printList([]string{"UID", "TITLE", "TYPE", "FOLDER", "TAGS"}, rows, results, 0)
+}
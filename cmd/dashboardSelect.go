@@ -0,0 +1,110 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"log"
+	"regexp"
+
+	"github.com/lstuker/grafana-tool/grafana"
+	"github.com/spf13/cobra"
+)
+
+// dashboardSelector selects dashboards for bulk commands. Criteria are
+// combined, a dashboard has to match all of them.
+type dashboardSelector struct {
+	UIDs       []string
+	Titles     []string
+	TitleRegex string
+	Folder     string
+	Tags       []string
+}
+
+// addDashboardSelectFlags adds the flags to select dashboards
+func addDashboardSelectFlags(cmd *cobra.Command, selector *dashboardSelector) {
+	cmd.Flags().StringSliceVar(&selector.UIDs, "uid", nil, "Dashboard UID, can be repeated")
+	cmd.Flags().StringSliceVar(&selector.Titles, "title", nil, "Exact dashboard title, can be repeated")
+	cmd.Flags().StringVar(&selector.TitleRegex, "title-regex", "", "Regular expression the dashboard title has to match")
+	cmd.Flags().StringVarP(&selector.Folder, "folder", "f", "", "Folder name, General for dashboards without folder")
+	cmd.Flags().StringSliceVar(&selector.Tags, "tag", nil, "Dashboard tag, can be repeated, all tags have to match")
+}
+
+// empty returns true if no criteria is set, which would select all dashboards
+func (s dashboardSelector) empty() bool {
+	return len(s.UIDs) == 0 && len(s.Titles) == 0 && s.TitleRegex == "" && s.Folder == "" && len(s.Tags) == 0
+}
+
+// selectDashboards returns the dashboards matching the selector
+func selectDashboards(c *grafana.Client, selector dashboardSelector) grafana.SearchResult {
+	var titleRegex *regexp.Regexp
+	if selector.TitleRegex != "" {
+		var err error
+		titleRegex, err = regexp.Compile(selector.TitleRegex)
+		if err != nil {
+			log.Fatalf("Invalid --title-regex: %s", err)
+		}
+	}
+
+	query := grafana.SearchQuery{Tags: selector.Tags, Type: "dash-db"}
+	if selector.Folder != "" {
+		query.FolderIDs = []int{findFolder(c, selector.Folder).ID}
+	}
+
+	searchResults, err := c.Search(query)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	selected := grafana.SearchResult{}
+	for _, result := range searchResults {
+		if len(selector.UIDs) > 0 && !contains(selector.UIDs, result.UID) {
+			continue
+		}
+		if len(selector.Titles) > 0 && !contains(selector.Titles, result.Title) {
+			continue
+		}
+		if titleRegex != nil && !titleRegex.MatchString(result.Title) {
+			continue
+		}
+		selected = append(selected, result)
+	}
+	return selected
+}
+
+// findFolder returns a folder by name, General is the folder of dashboards
+// without folder and has ID 0 and no UID
+func findFolder(c *grafana.Client, name string) grafana.FolderJSON {
+	if name == "General" {
+		return grafana.FolderJSON{Title: name}
+	}
+	folders, err := c.GetFolders()
+	if err != nil {
+		log.Fatal(err)
+	}
+	folder, err := folders.FolderFindByName(name)
+	if err != nil {
+		log.Fatalf("Folder %s not found", name)
+	}
+	return folder
+}
+
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
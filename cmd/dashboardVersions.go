@@ -0,0 +1,102 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/lstuker/grafana-tool/grafana"
+	"github.com/spf13/cobra"
+)
+
+// dashboardVersionsCmd represents the dashboard versions command
+var dashboardVersionsCmd = &cobra.Command{
+	Use:   "versions UID",
+	Short: "Lists the versions of a dashboard",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		listDashboardVersions(args[0])
+	},
+}
+
+// dashboardVersionsDiffCmd represents the dashboard versions diff command
+var dashboardVersionsDiffCmd = &cobra.Command{
+	Use:   "diff UID VERSION VERSION",
+	Short: "Shows the changes between two versions of a dashboard",
+	Args:  cobra.ExactArgs(3),
+	Run: func(cmd *cobra.Command, args []string) {
+		diffDashboardVersions(args[0], parseVersion(args[1]), parseVersion(args[2]))
+	},
+}
+
+func init() {
+	dashboardCmd.AddCommand(dashboardVersionsCmd)
+	dashboardVersionsCmd.AddCommand(dashboardVersionsDiffCmd)
+}
+
+func parseVersion(value string) int {
+	version, err := strconv.Atoi(value)
+	if err != nil {
+		log.Fatalf("Invalid version %s", value)
+	}
+	return version
+}
+
+func listDashboardVersions(uid string) {
+	c := newClient()
+	versions, err := c.GetDashboardVersions(uid)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	rows := [][]string{}
+	for _, version := range versions {
+		message := version.Message
+		if version.RestoredFrom != 0 && message == "" {
+			message = fmt.Sprintf("Restored from version %d", version.RestoredFrom)
+		}
+		rows = append(rows, []string{
+			strconv.Itoa(version.Version),
+			version.Created.Format("2006-01-02 15:04:05"),
+			version.CreatedBy,
+			message,
+		})
+	}
+	printList([]string{"VERSION", "DATE", "AUTHOR", "MESSAGE"}, rows, versions, 0)
+}
+
+func diffDashboardVersions(uid string, from int, to int) {
+	c := newClient()
+	older, err := c.GetDashboardVersion(uid, from)
+	if err != nil {
+		log.Fatal(err)
+	}
+	newer, err := c.GetDashboardVersion(uid, to)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// the version number always differs
+	delete(older.Data, "version")
+	delete(newer.Data, "version")
+
+	fmt.Printf("--- version %d by %s at %s\n", older.Version, older.CreatedBy, older.Created.Format("2006-01-02 15:04:05"))
+	fmt.Printf("+++ version %d by %s at %s\n", newer.Version, newer.CreatedBy, newer.Created.Format("2006-01-02 15:04:05"))
+	for _, change := range grafana.DiffJSON(older.Data, newer.Data) {
+		fmt.Println(change)
+	}
+}
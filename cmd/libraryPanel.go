@@ -0,0 +1,56 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/lstuker/grafana-tool/grafana"
+	"github.com/spf13/cobra"
+)
+
+// libraryPanelCmd represents the library-panel command
+var libraryPanelCmd = &cobra.Command{
+	Use:   "library-panel",
+	Short: "Manage Grafana library panels",
+	Long:  `Manage Grafana library panels`,
+}
+
+func init() {
+	rootCmd.AddCommand(libraryPanelCmd)
+}
+
+// writeLibraryPanel saves a library panel as <dir>/<uid>.json
+func writeLibraryPanel(dir string, panel grafana.LibraryPanelJSON) {
+	data, err := json.MarshalIndent(panel, "", "  ")
+	if err != nil {
+		log.Fatal(err)
+	}
+	err = os.MkdirAll(dir, 0755)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	filePath := filepath.Join(dir, panel.UID+".json")
+	log.Printf("Writing library panel to: %s\n", filePath)
+	err = ioutil.WriteFile(filePath, data, 0644)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"log"
+
+	"github.com/lstuker/grafana-tool/grafana"
+	"github.com/spf13/cobra"
+)
+
+var libraryPanelExportPath string
+
+// libraryPanelExportCmd represents the library-panel export command
+var libraryPanelExportCmd = &cobra.Command{
+	Use:   "export [UID...]",
+	Short: "Exports library panels, all of them if no UID is given",
+	Run: func(cmd *cobra.Command, args []string) {
+		exportLibraryPanels(args)
+	},
+}
+
+func init() {
+	libraryPanelCmd.AddCommand(libraryPanelExportCmd)
+	libraryPanelExportCmd.Flags().StringVarP(&libraryPanelExportPath, "path", "p", "", "Path to save library panels (required)")
+	libraryPanelExportCmd.MarkFlagRequired("path")
+}
+
+func exportLibraryPanels(uids []string) {
+	c := newClient()
+
+	if len(uids) == 0 {
+		panels, err := c.GetLibraryPanels()
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, panel := range panels {
+			writeLibraryPanel(libraryPanelExportPath, panel)
+		}
+		return
+	}
+
+	for _, uid := range uids {
+		panel, err := c.GetLibraryPanelByUID(uid)
+		if err != nil {
+			log.Fatal(err)
+		}
+		writeLibraryPanel(libraryPanelExportPath, panel)
+	}
+}
+
+// exportDashboardLibraryPanels exports the library panels used by a
+// dashboard and were not exported yet
+func exportDashboardLibraryPanels(c *grafana.Client, dir string, dashboard grafana.DashboardJSON, exported map[string]bool) {
+	for _, uid := range dashboard.LibraryPanelUIDs() {
+		if exported[uid] {
+			continue
+		}
+		panel, err := c.GetLibraryPanelByUID(uid)
+		if err != nil {
+			log.Fatal(err)
+		}
+		writeLibraryPanel(dir, panel)
+		exported[uid] = true
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"log"
+
+	"github.com/lstuker/grafana-tool/grafana"
+	"github.com/spf13/cobra"
+)
+
+// libraryPanelImportCmd represents the library-panel import command
+var libraryPanelImportCmd = &cobra.Command{
+	Use:   "import FILE...",
+	Short: "Imports library panels, existing ones with the same UID are updated",
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		importLibraryPanels(args)
+	},
+}
+
+func init() {
+	libraryPanelCmd.AddCommand(libraryPanelImportCmd)
+}
+
+func importLibraryPanels(files []string) {
+	c := newClient()
+
+	for _, file := range files {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			log.Fatal(err)
+		}
+		var panel grafana.LibraryPanelJSON
+		err = json.Unmarshal(data, &panel)
+		if err != nil {
+			log.Fatalf("%s: %s", file, err)
+		}
+
+		existing, err := c.GetLibraryPanelByUID(panel.UID)
+		if err != nil && err != grafana.ErrLibraryPanelNotFound {
+			log.Fatalf("%s: %s", panel.UID, err)
+		}
+		if err == nil {
+			_, err = c.UpdateLibraryPanel(panel, existing.Version)
+			if err != nil {
+				log.Fatal(err)
+			}
+			log.Printf("Updated library panel %s\n", panel.Name)
+			continue
+		}
+
+		_, err = c.CreateLibraryPanel(panel)
+		if err != nil {
+			log.Fatal(err)
+		}
+		log.Printf("Created library panel %s\n", panel.Name)
+	}
+}
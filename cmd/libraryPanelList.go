@@ -0,0 +1,55 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"log"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+// libraryPanelListCmd represents the library-panel list command
+var libraryPanelListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists library panels",
+	Run: func(cmd *cobra.Command, args []string) {
+		listLibraryPanels()
+	},
+}
+
+func init() {
+	libraryPanelCmd.AddCommand(libraryPanelListCmd)
+}
+
+func listLibraryPanels() {
+	c := newClient()
+	panels, err := c.GetLibraryPanels()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	rows := [][]string{}
+	for _, panel := range panels {
+		rows = append(rows, []string{
+			panel.UID,
+			panel.Name,
+			panel.Type,
+			panel.Meta.FolderName,
+			strconv.Itoa(panel.Meta.ConnectedDashboards),
+		})
+	}
+	printList([]string{"UID", "NAME", "TYPE", "FOLDER", "DASHBOARDS"}, rows, panels, 0)
+}
@@ -0,0 +1,90 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"log"
+
+	"github.com/lstuker/grafana-tool/grafana"
+	"github.com/spf13/cobra"
+)
+
+// libraryPanelUsageCmd represents the library-panel usage command
+var libraryPanelUsageCmd = &cobra.Command{
+	Use:   "usage [UID...]",
+	Short: "Reports which dashboards use each library panel",
+	Run: func(cmd *cobra.Command, args []string) {
+		libraryPanelUsage(args)
+	},
+}
+
+func init() {
+	libraryPanelCmd.AddCommand(libraryPanelUsageCmd)
+}
+
+func libraryPanelUsage(uids []string) {
+	c := newClient()
+
+	panels := grafana.LibraryPanelListJSON{}
+	if len(uids) == 0 {
+		var err error
+		panels, err = c.GetLibraryPanels()
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+	for _, uid := range uids {
+		panel, err := c.GetLibraryPanelByUID(uid)
+		if err != nil {
+			log.Fatal(err)
+		}
+		panels = append(panels, panel)
+	}
+
+	searchResults, err := c.SearchDashboard("", "", "dash-db")
+	if err != nil {
+		log.Fatal(err)
+	}
+	byUID := map[string]int{}
+	byID := map[int]int{}
+	for i, result := range searchResults {
+		byUID[result.UID] = i
+		byID[result.ID] = i
+	}
+
+	rows := [][]string{}
+	for _, panel := range panels {
+		connections, err := c.GetLibraryPanelConnections(panel.UID)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if len(connections) == 0 {
+			rows = append(rows, []string{panel.UID, panel.Name, "", "(unused)", ""})
+		}
+		for _, connection := range connections {
+			i, ok := byUID[connection.ConnectionUID]
+			if !ok {
+				i, ok = byID[connection.ConnectionID]
+			}
+			if !ok {
+				rows = append(rows, []string{panel.UID, panel.Name, connection.ConnectionUID, "(unknown)", ""})
+				continue
+			}
+			result := searchResults[i]
+			rows = append(rows, []string{panel.UID, panel.Name, result.UID, result.Title, result.FolderTitle})
+		}
+	}
+	printList([]string{"UID", "NAME", "DASHBOARD UID", "DASHBOARD", "FOLDER"}, rows, nil, 2)
+}
@@ -0,0 +1,162 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"log"
+
+	"github.com/lstuker/grafana-tool/grafana"
+	"github.com/spf13/cobra"
+)
+
+// orgContent is everything of an organisation which is exported and cloned
+type orgContent struct {
+	Org         grafana.OrgJSON            `json:"org"`
+	Folders     grafana.FolderListJSON     `json:"folders"`
+	Dashboards  []grafana.DashboardRawJSON `json:"dashboards"`
+	Datasources grafana.DatasourceListJSON `json:"datasources"`
+	Teams       grafana.TeamListJSON       `json:"teams"`
+	Preferences grafana.PreferencesJSON    `json:"preferences"`
+}
+
+// orgCmd represents the org command
+var orgCmd = &cobra.Command{
+	Use:   "org",
+	Short: "Manage Grafana organisations",
+	Long: `Manage Grafana organisations
+
+Most org commands need a Grafana admin, use --user and --password.`,
+}
+
+func init() {
+	rootCmd.AddCommand(orgCmd)
+}
+
+// findOrg returns the organisation with the given name or ID or exits
+func findOrg(c *grafana.Client, nameOrID string) grafana.OrgJSON {
+	orgs, err := c.GetOrgs()
+	if err != nil {
+		log.Fatal(err)
+	}
+	org, err := orgs.OrgFind(nameOrID)
+	if err != nil {
+		log.Fatalf("%s: %s", err, nameOrID)
+	}
+	return org
+}
+
+// readOrg reads folders, dashboards, datasources, teams and preferences of
+// the organisation the client is working with
+func readOrg(c *grafana.Client) orgContent {
+	var content orgContent
+	var err error
+
+	content.Org, err = c.GetCurrentOrg()
+	if err != nil {
+		log.Fatal(err)
+	}
+	content.Folders, err = c.GetFolders()
+	if err != nil {
+		log.Fatal(err)
+	}
+	content.Datasources, err = c.GetDatasources()
+	if err != nil {
+		log.Fatal(err)
+	}
+	content.Teams, err = c.GetTeams()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	searchResults, err := c.SearchDashboard("", "", "dash-db")
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, result := range searchResults {
+		dashboard, err := c.GetDashboardRawByUID(result.UID)
+		if err != nil {
+			log.Fatal(err)
+		}
+		content.Dashboards = append(content.Dashboards, dashboard)
+	}
+
+	content.Preferences, err = c.GetOrgPreferences()
+	if err != nil {
+		log.Fatal(err)
+	}
+	uids := map[int]string{}
+	for _, result := range searchResults {
+		uids[result.ID] = result.UID
+	}
+	content.Preferences = content.Preferences.WithHomeDashboardUID(uids)
+	return content
+}
+
+// writeOrg creates the content of an organisation in the organisation the
+// client is working with. Datasource secrets and team members are not copied.
+func writeOrg(c *grafana.Client, content orgContent) {
+	folders := map[int]grafana.FolderJSON{}
+	for _, folder := range content.Folders {
+		created, err := c.CreateFolder(folder.UID, folder.Title)
+		if err != nil {
+			log.Fatal(err)
+		}
+		folders[folder.ID] = created
+		log.Printf("Created folder %s\n", folder.Title)
+	}
+
+	for _, datasource := range content.Datasources {
+		_, err := c.CreateDatasource(datasource)
+		if err != nil {
+			log.Fatal(err)
+		}
+		log.Printf("Created datasource %s\n", datasource.Name)
+		for field := range datasource.SecureJSONFields {
+			log.Printf("Warning: secret %s of datasource %s was not copied\n", field, datasource.Name)
+		}
+	}
+
+	dashboardIDs := map[string]int{}
+	for _, dashboard := range content.Dashboards {
+		dashboard.Dashboard["id"] = nil
+		folder := folders[dashboard.Meta.FolderID]
+		result, err := c.SaveDashboard(grafana.DashboardSaveJSON{
+			Dashboard: dashboard.Dashboard,
+			FolderID:  folder.ID,
+			FolderUID: folder.UID,
+			Message:   "Copied from organisation " + content.Org.Name,
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+		dashboardIDs[result.UID] = result.ID
+		log.Printf("Created dashboard %v\n", dashboard.Dashboard["title"])
+	}
+
+	for _, team := range content.Teams {
+		_, err := c.CreateTeam(team.Name, team.Email)
+		if err != nil {
+			log.Fatal(err)
+		}
+		log.Printf("Created team %s without members\n", team.Name)
+	}
+
+	preferences, _ := content.Preferences.WithHomeDashboardID(dashboardIDs)
+	err := c.UpdateOrgPreferences(preferences)
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("Updated preferences\n")
+}
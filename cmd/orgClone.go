@@ -0,0 +1,54 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"log"
+
+	"github.com/spf13/cobra"
+)
+
+// orgCloneCmd represents the org clone command
+var orgCloneCmd = &cobra.Command{
+	Use:   "clone SOURCE_NAME_OR_ID NEW_NAME",
+	Short: "Creates a new organisation as copy of an existing one",
+	Long: `Creates a new organisation and copies folders, dashboards, datasources,
+teams and preferences of the source organisation into it.
+
+Datasource secrets and team members are not copied. The user running the
+clone must be member of the source organisation.`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		cloneOrg(args[0], args[1])
+	},
+}
+
+func init() {
+	orgCmd.AddCommand(orgCloneCmd)
+}
+
+func cloneOrg(source string, name string) {
+	c := newClient()
+	org := findOrg(c, source)
+	content := readOrg(c.WithOrg(org.ID))
+
+	id, err := c.CreateOrg(name)
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("Created organisation %s with ID %d\n", name, id)
+
+	writeOrg(c.WithOrg(id), content)
+}
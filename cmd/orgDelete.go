@@ -0,0 +1,86 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+var orgDeleteYes bool
+
+// orgDeleteCmd represents the org delete command
+var orgDeleteCmd = &cobra.Command{
+	Use:   "delete NAME_OR_ID",
+	Short: "Deletes an organisation with all its dashboards, datasources and teams",
+	Long: `Deletes an organisation with all its dashboards, datasources and teams. The
+organisation is shown first with the number of its dashboards, datasources,
+teams and users and has to be confirmed unless --yes is given.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		deleteOrg(args[0])
+	},
+}
+
+func init() {
+	orgCmd.AddCommand(orgDeleteCmd)
+	orgDeleteCmd.Flags().BoolVarP(&orgDeleteYes, "yes", "y", false, "Delete without confirmation, for scripts")
+}
+
+func deleteOrg(nameOrID string) {
+	c := newClient()
+	org := findOrg(c, nameOrID)
+
+	orgClient := c.WithOrg(org.ID)
+	dashboards, err := orgClient.SearchDashboard("", "", "dash-db")
+	if err != nil {
+		log.Fatal(err)
+	}
+	datasources, err := orgClient.GetDatasources()
+	if err != nil {
+		log.Fatal(err)
+	}
+	teams, err := orgClient.GetTeams()
+	if err != nil {
+		log.Fatal(err)
+	}
+	users, err := orgClient.GetOrgUsers(org.ID)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	log.Println("This organisation will be deleted:")
+	printTable([]string{"ID", "NAME", "DASHBOARDS", "DATASOURCES", "TEAMS", "USERS"}, [][]string{{
+		strconv.Itoa(org.ID),
+		org.Name,
+		strconv.Itoa(len(dashboards)),
+		strconv.Itoa(len(datasources)),
+		strconv.Itoa(len(teams)),
+		strconv.Itoa(len(users)),
+	}})
+	if !orgDeleteYes && !confirm(fmt.Sprintf("Delete organisation %s?", org.Name)) {
+		log.Println("Aborted")
+		return
+	}
+
+	err = c.DeleteOrg(org.ID)
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("Deleted organisation %s\n", org.Name)
+}
@@ -0,0 +1,58 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"log"
+
+	"github.com/spf13/cobra"
+)
+
+var orgExportPath string
+
+// orgExportCmd represents the org export command
+var orgExportCmd = &cobra.Command{
+	Use:   "export NAME_OR_ID",
+	Short: "Exports folders, dashboards, datasources, teams and preferences of an organisation",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		exportOrg(args[0])
+	},
+}
+
+func init() {
+	orgCmd.AddCommand(orgExportCmd)
+	orgExportCmd.Flags().StringVarP(&orgExportPath, "path", "p", "", "File to save the organisation (required)")
+	orgExportCmd.MarkFlagRequired("path")
+}
+
+func exportOrg(nameOrID string) {
+	c := newClient()
+	org := findOrg(c, nameOrID)
+	content := readOrg(c.WithOrg(org.ID))
+
+	data, err := json.MarshalIndent(content, "", "  ")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	log.Printf("Writing organisation %s to: %s\n", org.Name, orgExportPath)
+	err = ioutil.WriteFile(orgExportPath, data, 0644)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
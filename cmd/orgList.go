@@ -0,0 +1,49 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"log"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+// orgListCmd represents the org list command
+var orgListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists organisations",
+	Run: func(cmd *cobra.Command, args []string) {
+		listOrgs()
+	},
+}
+
+func init() {
+	orgCmd.AddCommand(orgListCmd)
+}
+
+func listOrgs() {
+	c := newClient()
+	orgs, err := c.GetOrgs()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	rows := [][]string{}
+	for _, org := range orgs {
+		rows = append(rows, []string{strconv.Itoa(org.ID), org.Name})
+	}
+	printList([]string{"ID", "NAME"}, rows, orgs, 0)
+}
@@ -0,0 +1,125 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// outputFormat is the format of list commands set with the global --output flag
+var outputFormat string
+
+// outputFormats are the valid values of --output
+var outputFormats = []string{"table", "json", "yaml", "csv", "name"}
+
+// printTable writes rows as aligned columns to stdout
+func printTable(header []string, rows [][]string) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, strings.Join(header, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	w.Flush()
+}
+
+// printList writes the result of a list command in the format given by
+// --output. table and csv write the rows, name the column nameColumn which
+// identifies a row, every value once and without empty values. json and
+// yaml write records as returned by Grafana, if records is nil the rows are
+// written as objects with the lower case header as keys.
+func printList(header []string, rows [][]string, records interface{}, nameColumn int) {
+	switch outputFormat {
+	case "json", "yaml":
+		if records == nil {
+			records = rowObjects(header, rows)
+		}
+		printRecords(records)
+	case "csv":
+		w := csv.NewWriter(os.Stdout)
+		w.Write(header)
+		w.WriteAll(rows)
+		if err := w.Error(); err != nil {
+			log.Fatal(err)
+		}
+	case "name":
+		printed := map[string]bool{}
+		for _, row := range rows {
+			if row[nameColumn] != "" && !printed[row[nameColumn]] {
+				printed[row[nameColumn]] = true
+				fmt.Println(row[nameColumn])
+			}
+		}
+	default:
+		printTable(header, rows)
+	}
+}
+
+// printRecords writes records as indented JSON or as YAML with the keys of
+// the JSON tags
+func printRecords(records interface{}) {
+	data, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		log.Fatal(err)
+	}
+	if outputFormat == "json" {
+		fmt.Println(string(data))
+		return
+	}
+
+	var generic interface{}
+	err = json.Unmarshal(data, &generic)
+	if err != nil {
+		log.Fatal(err)
+	}
+	data, err = yaml.Marshal(generic)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Print(string(data))
+}
+
+// rowObjects returns the rows as objects, ex: header "LAST USED" becomes
+// the key last_used
+func rowObjects(header []string, rows [][]string) []map[string]string {
+	objects := []map[string]string{}
+	for _, row := range rows {
+		object := map[string]string{}
+		for i, column := range header {
+			if i < len(row) {
+				object[strings.ToLower(strings.Replace(column, " ", "_", -1))] = row[i]
+			}
+		}
+		objects = append(objects, object)
+	}
+	return objects
+}
+
+// validOutputFormat returns true if format is a supported --output value
+func validOutputFormat(format string) bool {
+	for _, valid := range outputFormats {
+		if format == valid {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,48 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"log"
+
+	"github.com/lstuker/grafana-tool/grafana"
+	"github.com/spf13/cobra"
+)
+
+// playlistCmd represents the playlist command
+var playlistCmd = &cobra.Command{
+	Use:   "playlist",
+	Short: "Manage Grafana playlists",
+	Long:  `Manage Grafana playlists`,
+}
+
+func init() {
+	rootCmd.AddCommand(playlistCmd)
+}
+
+// dashboardIDMaps returns all dashboards of Grafana as ID to UID and UID to ID maps
+func dashboardIDMaps(c *grafana.Client) (map[int]string, map[string]int) {
+	searchResults, err := c.SearchDashboard("", "", "dash-db")
+	if err != nil {
+		log.Fatal(err)
+	}
+	uids := map[int]string{}
+	ids := map[string]int{}
+	for _, result := range searchResults {
+		uids[result.ID] = result.UID
+		ids[result.UID] = result.ID
+	}
+	return uids, ids
+}
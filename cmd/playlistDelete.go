@@ -0,0 +1,44 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"log"
+
+	"github.com/spf13/cobra"
+)
+
+// playlistDeleteCmd represents the playlist delete command
+var playlistDeleteCmd = &cobra.Command{
+	Use:   "delete UID",
+	Short: "Deletes a playlist",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		deletePlaylist(args[0])
+	},
+}
+
+func init() {
+	playlistCmd.AddCommand(playlistDeleteCmd)
+}
+
+func deletePlaylist(uid string) {
+	c := newClient()
+	err := c.DeletePlaylist(uid)
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("Deleted playlist %s\n", uid)
+}
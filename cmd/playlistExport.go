@@ -0,0 +1,91 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/lstuker/grafana-tool/grafana"
+	"github.com/spf13/cobra"
+)
+
+var playlistExportPath string
+
+// playlistExportCmd represents the playlist export command
+var playlistExportCmd = &cobra.Command{
+	Use:   "export [UID...]",
+	Short: "Exports playlists, all of them if no UID is given",
+	Long: `Exports playlists as <path>/<uid>.json, all of them if no UID is given.
+Dashboards are referenced by UID so the playlists can be imported on another Grafana.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		exportPlaylists(args)
+	},
+}
+
+func init() {
+	playlistCmd.AddCommand(playlistExportCmd)
+	playlistExportCmd.Flags().StringVarP(&playlistExportPath, "path", "p", "", "Path to save playlists (required)")
+	playlistExportCmd.MarkFlagRequired("path")
+}
+
+func exportPlaylists(uids []string) {
+	c := newClient()
+
+	if len(uids) == 0 {
+		playlists, err := c.GetPlaylists()
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, playlist := range playlists {
+			uids = append(uids, playlist.UID)
+		}
+	}
+
+	dashboardUIDs, _ := dashboardIDMaps(c)
+	err := os.MkdirAll(playlistExportPath, 0755)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, uid := range uids {
+		playlist, err := c.GetPlaylistByUID(uid)
+		if err != nil {
+			log.Fatal(err)
+		}
+		playlist, missing := playlist.WithDashboardUIDs(dashboardUIDs)
+		warnMissingPlaylistItems(playlist, missing)
+
+		data, err := json.MarshalIndent(playlist, "", "  ")
+		if err != nil {
+			log.Fatal(err)
+		}
+		filePath := filepath.Join(playlistExportPath, playlist.UID+".json")
+		log.Printf("Writing playlist to: %s\n", filePath)
+		err = ioutil.WriteFile(filePath, data, 0644)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+func warnMissingPlaylistItems(playlist grafana.PlaylistJSON, missing []grafana.PlaylistItemJSON) {
+	for _, item := range missing {
+		log.Printf("Warning: playlist %s references the missing dashboard %s, the item is dropped\n", playlist.Name, item.Value)
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"log"
+
+	"github.com/lstuker/grafana-tool/grafana"
+	"github.com/spf13/cobra"
+)
+
+// playlistImportCmd represents the playlist import command
+var playlistImportCmd = &cobra.Command{
+	Use:   "import FILE...",
+	Short: "Imports playlists, existing ones with the same UID are updated",
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		importPlaylists(args)
+	},
+}
+
+func init() {
+	playlistCmd.AddCommand(playlistImportCmd)
+}
+
+func importPlaylists(files []string) {
+	c := newClient()
+	_, dashboardIDs := dashboardIDMaps(c)
+
+	for _, file := range files {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			log.Fatal(err)
+		}
+		var playlist grafana.PlaylistJSON
+		err = json.Unmarshal(data, &playlist)
+		if err != nil {
+			log.Fatalf("%s: %s", file, err)
+		}
+
+		playlist, missing := playlist.WithDashboardIDs(dashboardIDs)
+		warnMissingPlaylistItems(playlist, missing)
+		for _, item := range playlist.Items {
+			if item.Type != grafana.PlaylistItemDashboardByTag {
+				continue
+			}
+			results, err := c.Search(grafana.SearchQuery{Tags: []string{item.Value}, Type: "dash-db"})
+			if err != nil {
+				log.Fatal(err)
+			}
+			if len(results) == 0 {
+				log.Printf("Warning: playlist %s references the tag %s, but no dashboard has this tag\n", playlist.Name, item.Value)
+			}
+		}
+
+		_, err = c.GetPlaylistByUID(playlist.UID)
+		if err != nil && err != grafana.ErrPlaylistNotFound {
+			log.Fatalf("%s: %s", playlist.UID, err)
+		}
+		if err == nil {
+			_, err = c.UpdatePlaylist(playlist)
+			if err != nil {
+				log.Fatal(err)
+			}
+			log.Printf("Updated playlist %s\n", playlist.Name)
+			continue
+		}
+
+		_, err = c.CreatePlaylist(playlist)
+		if err != nil {
+			log.Fatal(err)
+		}
+		log.Printf("Created playlist %s\n", playlist.Name)
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"log"
+
+	"github.com/spf13/cobra"
+)
+
+// playlistListCmd represents the playlist list command
+var playlistListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists playlists",
+	Run: func(cmd *cobra.Command, args []string) {
+		listPlaylists()
+	},
+}
+
+func init() {
+	playlistCmd.AddCommand(playlistListCmd)
+}
+
+func listPlaylists() {
+	c := newClient()
+	playlists, err := c.GetPlaylists()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	rows := [][]string{}
+	for _, playlist := range playlists {
+		rows = append(rows, []string{playlist.UID, playlist.Name, playlist.Interval})
+	}
+	printList([]string{"UID", "NAME", "INTERVAL"}, rows, playlists, 0)
+}
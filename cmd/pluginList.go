@@ -0,0 +1,52 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"log"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+var pluginType string
+
+// pluginListCmd represents the plugin list command
+var pluginListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists installed plugins including the core plugins",
+	Run: func(cmd *cobra.Command, args []string) {
+		listPlugins()
+	},
+}
+
+func init() {
+	pluginCmd.AddCommand(pluginListCmd)
+	pluginListCmd.Flags().StringVar(&pluginType, "type", "", "Only plugins of type: panel, datasource or app")
+}
+
+func listPlugins() {
+	c := newClient()
+	plugins, err := c.GetPlugins(pluginType)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	rows := [][]string{}
+	for _, plugin := range plugins {
+		rows = append(rows, []string{plugin.ID, plugin.Name, plugin.Type, plugin.Info.Version, strconv.FormatBool(plugin.Enabled)})
+	}
+	printList([]string{"ID", "NAME", "TYPE", "VERSION", "ENABLED"}, rows, plugins, 0)
+}
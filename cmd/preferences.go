@@ -0,0 +1,97 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"log"
+
+	"github.com/lstuker/grafana-tool/grafana"
+	"github.com/spf13/cobra"
+)
+
+var preferencesScope string
+var preferencesTeam string
+
+// preferencesFile are the exported preferences, teams are referenced by name
+type preferencesFile struct {
+	Org   *grafana.PreferencesJSON           `json:"org,omitempty"`
+	Teams map[string]grafana.PreferencesJSON `json:"teams,omitempty"`
+	User  *grafana.PreferencesJSON           `json:"user,omitempty"`
+}
+
+// preferencesCmd represents the preferences command
+var preferencesCmd = &cobra.Command{
+	Use:   "preferences",
+	Short: "Manage preferences of the organisation, teams and the user",
+	Long: `Manage home dashboard, theme, timezone and week start of the organisation,
+of teams and of the authenticated user`,
+}
+
+func init() {
+	rootCmd.AddCommand(preferencesCmd)
+}
+
+// addPreferencesScopeFlags adds the flags to choose whose preferences are used
+func addPreferencesScopeFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&preferencesScope, "scope", "org", "Preferences of: org, team or user")
+	cmd.Flags().StringVar(&preferencesTeam, "team", "", "Team name for --scope team")
+}
+
+// getScopedPreferences returns the preferences selected by --scope
+func getScopedPreferences(c *grafana.Client) grafana.PreferencesJSON {
+	var preferences grafana.PreferencesJSON
+	var err error
+
+	switch preferencesScope {
+	case "org":
+		preferences, err = c.GetOrgPreferences()
+	case "team":
+		preferences, err = c.GetTeamPreferences(scopedTeam(c).ID)
+	case "user":
+		preferences, err = c.GetUserPreferences()
+	default:
+		log.Fatalf("Invalid scope %s, use org, team or user", preferencesScope)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	return preferences
+}
+
+// updateScopedPreferences replaces the preferences selected by --scope
+func updateScopedPreferences(c *grafana.Client, preferences grafana.PreferencesJSON) {
+	var err error
+
+	switch preferencesScope {
+	case "org":
+		err = c.UpdateOrgPreferences(preferences)
+	case "team":
+		err = c.UpdateTeamPreferences(scopedTeam(c).ID, preferences)
+	case "user":
+		err = c.UpdateUserPreferences(preferences)
+	default:
+		log.Fatalf("Invalid scope %s, use org, team or user", preferencesScope)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func scopedTeam(c *grafana.Client) grafana.TeamJSON {
+	if preferencesTeam == "" {
+		log.Fatal("Missing --team for --scope team")
+	}
+	return findTeam(c, preferencesTeam)
+}
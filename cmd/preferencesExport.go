@@ -0,0 +1,86 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"log"
+
+	"github.com/lstuker/grafana-tool/grafana"
+	"github.com/spf13/cobra"
+)
+
+var preferencesExportPath string
+
+// preferencesExportCmd represents the preferences export command
+var preferencesExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Exports the preferences of the organisation, all teams and the user",
+	Long: `Exports the preferences of the organisation, all teams and the authenticated
+user. The home dashboard is referenced by UID so the file can be imported on
+another Grafana.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		exportPreferences()
+	},
+}
+
+func init() {
+	preferencesCmd.AddCommand(preferencesExportCmd)
+	preferencesExportCmd.Flags().StringVarP(&preferencesExportPath, "path", "p", "", "File to save the preferences (required)")
+	preferencesExportCmd.MarkFlagRequired("path")
+}
+
+func exportPreferences() {
+	c := newClient()
+	uids, _ := dashboardIDMaps(c)
+	export := preferencesFile{Teams: map[string]grafana.PreferencesJSON{}}
+
+	org, err := c.GetOrgPreferences()
+	if err != nil {
+		log.Fatal(err)
+	}
+	org = org.WithHomeDashboardUID(uids)
+	export.Org = &org
+
+	teams, err := c.GetTeams()
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, team := range teams {
+		preferences, err := c.GetTeamPreferences(team.ID)
+		if err != nil {
+			log.Fatal(err)
+		}
+		export.Teams[team.Name] = preferences.WithHomeDashboardUID(uids)
+	}
+
+	user, err := c.GetUserPreferences()
+	if err != nil {
+		log.Fatal(err)
+	}
+	user = user.WithHomeDashboardUID(uids)
+	export.User = &user
+
+	data, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("Writing preferences to: %s\n", preferencesExportPath)
+	err = ioutil.WriteFile(preferencesExportPath, data, 0644)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
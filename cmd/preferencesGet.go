@@ -0,0 +1,51 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+// preferencesGetCmd represents the preferences get command
+var preferencesGetCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Shows preferences",
+	Run: func(cmd *cobra.Command, args []string) {
+		getPreferences()
+	},
+}
+
+func init() {
+	preferencesCmd.AddCommand(preferencesGetCmd)
+	addPreferencesScopeFlags(preferencesGetCmd)
+}
+
+func getPreferences() {
+	c := newClient()
+	uids, _ := dashboardIDMaps(c)
+	preferences := getScopedPreferences(c)
+	homeID := preferences.HomeDashboardID
+	preferences = preferences.WithHomeDashboardUID(uids)
+
+	printList([]string{"PREFERENCE", "VALUE"}, [][]string{
+		{"theme", preferences.Theme},
+		{"homeDashboardUID", preferences.HomeDashboardUID},
+		{"homeDashboardId", strconv.Itoa(homeID)},
+		{"timezone", preferences.Timezone},
+		{"weekStart", preferences.WeekStart},
+	}, preferences, 1)
+}
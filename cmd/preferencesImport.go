@@ -0,0 +1,99 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"log"
+
+	"github.com/lstuker/grafana-tool/grafana"
+	"github.com/spf13/cobra"
+)
+
+var preferencesImportPath string
+
+// preferencesImportCmd represents the preferences import command
+var preferencesImportCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Imports preferences written by preferences export",
+	Run: func(cmd *cobra.Command, args []string) {
+		importPreferences()
+	},
+}
+
+func init() {
+	preferencesCmd.AddCommand(preferencesImportCmd)
+	preferencesImportCmd.Flags().StringVarP(&preferencesImportPath, "path", "p", "", "File with the exported preferences (required)")
+	preferencesImportCmd.MarkFlagRequired("path")
+}
+
+func importPreferences() {
+	data, err := ioutil.ReadFile(preferencesImportPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var imported preferencesFile
+	err = json.Unmarshal(data, &imported)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	c := newClient()
+	_, ids := dashboardIDMaps(c)
+	withHomeDashboard := func(preferences grafana.PreferencesJSON, owner string) grafana.PreferencesJSON {
+		preferences, ok := preferences.WithHomeDashboardID(ids)
+		if !ok {
+			log.Printf("Warning: home dashboard %s of %s not found, the default is used\n", preferences.HomeDashboardUID, owner)
+			preferences.HomeDashboardUID = ""
+		}
+		return preferences
+	}
+
+	if imported.Org != nil {
+		err = c.UpdateOrgPreferences(withHomeDashboard(*imported.Org, "the organisation"))
+		if err != nil {
+			log.Fatal(err)
+		}
+		log.Printf("Updated organisation preferences\n")
+	}
+
+	if len(imported.Teams) > 0 {
+		teams, err := c.GetTeams()
+		if err != nil {
+			log.Fatal(err)
+		}
+		for name, preferences := range imported.Teams {
+			team, err := teams.TeamFindByName(name)
+			if err != nil {
+				log.Printf("Warning: team %s not found, its preferences are skipped\n", name)
+				continue
+			}
+			err = c.UpdateTeamPreferences(team.ID, withHomeDashboard(preferences, "team "+name))
+			if err != nil {
+				log.Fatal(err)
+			}
+			log.Printf("Updated preferences of team %s\n", name)
+		}
+	}
+
+	if imported.User != nil {
+		err = c.UpdateUserPreferences(withHomeDashboard(*imported.User, "the user"))
+		if err != nil {
+			log.Fatal(err)
+		}
+		log.Printf("Updated user preferences\n")
+	}
+}
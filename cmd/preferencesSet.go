@@ -0,0 +1,70 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"log"
+
+	"github.com/spf13/cobra"
+)
+
+var preferencesTheme string
+var preferencesTimezone string
+var preferencesWeekStart string
+var preferencesHomeDashboard string
+
+// preferencesSetCmd represents the preferences set command
+var preferencesSetCmd = &cobra.Command{
+	Use:   "set",
+	Short: "Changes preferences, preferences without flag are kept",
+	Run: func(cmd *cobra.Command, args []string) {
+		setPreferences(cmd)
+	},
+}
+
+func init() {
+	preferencesCmd.AddCommand(preferencesSetCmd)
+	addPreferencesScopeFlags(preferencesSetCmd)
+	preferencesSetCmd.Flags().StringVar(&preferencesTheme, "theme", "", "Theme: light, dark or empty for the default")
+	preferencesSetCmd.Flags().StringVar(&preferencesTimezone, "timezone", "", "Timezone: utc, browser or empty for the default")
+	preferencesSetCmd.Flags().StringVar(&preferencesWeekStart, "week-start", "", "First day of the week, ex: monday")
+	preferencesSetCmd.Flags().StringVar(&preferencesHomeDashboard, "home-dashboard", "", "UID of the home dashboard")
+}
+
+func setPreferences(cmd *cobra.Command) {
+	c := newClient()
+	uids, ids := dashboardIDMaps(c)
+	preferences := getScopedPreferences(c).WithHomeDashboardUID(uids)
+
+	if cmd.Flags().Changed("theme") {
+		preferences.Theme = preferencesTheme
+	}
+	if cmd.Flags().Changed("timezone") {
+		preferences.Timezone = preferencesTimezone
+	}
+	if cmd.Flags().Changed("week-start") {
+		preferences.WeekStart = preferencesWeekStart
+	}
+	if cmd.Flags().Changed("home-dashboard") {
+		preferences.HomeDashboardUID = preferencesHomeDashboard
+	}
+
+	preferences, ok := preferences.WithHomeDashboardID(ids)
+	if !ok {
+		log.Fatalf("Home dashboard %s not found", preferences.HomeDashboardUID)
+	}
+	updateScopedPreferences(c, preferences)
+	log.Printf("Updated %s preferences\n", preferencesScope)
+}
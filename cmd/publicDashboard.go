@@ -0,0 +1,63 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"log"
+	"strconv"
+
+	"github.com/lstuker/grafana-tool/grafana"
+	"github.com/spf13/cobra"
+)
+
+// publicDashboardCmd represents the public-dashboard command
+var publicDashboardCmd = &cobra.Command{
+	Use:   "public-dashboard",
+	Short: "Manage publicly shared Grafana dashboards",
+	Long:  `Manage publicly shared Grafana dashboards`,
+}
+
+func init() {
+	rootCmd.AddCommand(publicDashboardCmd)
+}
+
+var publicDashboardHeader = []string{"DASHBOARD UID", "TITLE", "ENABLED", "ACCESS TOKEN"}
+
+// publicDashboardRows returns the table rows for a list of public
+// dashboards, access tokens are not shown
+func publicDashboardRows(publicDashboards grafana.PublicDashboardListJSON) [][]string {
+	rows := [][]string{}
+	for _, public := range publicDashboards {
+		token := "no"
+		if public.AccessToken != "" {
+			token = "yes"
+		}
+		rows = append(rows, []string{public.DashboardUID, public.Title, strconv.FormatBool(public.IsEnabled), token})
+	}
+	return rows
+}
+
+// findPublicDashboard returns the public configuration of a dashboard or
+// exits if the dashboard was never shared
+func findPublicDashboard(c *grafana.Client, dashboardUID string) grafana.PublicDashboardJSON {
+	public, err := c.GetPublicDashboard(dashboardUID)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if public.UID == "" {
+		log.Fatalf("Dashboard %s is not shared publicly", dashboardUID)
+	}
+	return public
+}
@@ -0,0 +1,45 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"log"
+
+	"github.com/spf13/cobra"
+)
+
+// publicDashboardDeleteCmd represents the public-dashboard delete command
+var publicDashboardDeleteCmd = &cobra.Command{
+	Use:   "delete DASHBOARD_UID",
+	Short: "Removes the public sharing of a dashboard and its access token",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		deletePublicDashboard(args[0])
+	},
+}
+
+func init() {
+	publicDashboardCmd.AddCommand(publicDashboardDeleteCmd)
+}
+
+func deletePublicDashboard(dashboardUID string) {
+	c := newClient()
+	public := findPublicDashboard(c, dashboardUID)
+	err := c.DeletePublicDashboard(dashboardUID, public.UID)
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("Public sharing of dashboard %s is deleted\n", dashboardUID)
+}
@@ -0,0 +1,45 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"log"
+
+	"github.com/spf13/cobra"
+)
+
+// publicDashboardDisableCmd represents the public-dashboard disable command
+var publicDashboardDisableCmd = &cobra.Command{
+	Use:   "disable DASHBOARD_UID",
+	Short: "Pauses the public access to a dashboard, the access token is kept",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		disablePublicDashboard(args[0])
+	},
+}
+
+func init() {
+	publicDashboardCmd.AddCommand(publicDashboardDisableCmd)
+}
+
+func disablePublicDashboard(dashboardUID string) {
+	c := newClient()
+	public := findPublicDashboard(c, dashboardUID)
+	_, err := c.UpdatePublicDashboard(dashboardUID, public.UID, false)
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("Public access to dashboard %s is disabled\n", dashboardUID)
+}
@@ -0,0 +1,53 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"log"
+
+	"github.com/spf13/cobra"
+)
+
+// publicDashboardEnableCmd represents the public-dashboard enable command
+var publicDashboardEnableCmd = &cobra.Command{
+	Use:   "enable DASHBOARD_UID",
+	Short: "Shares a dashboard publicly",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		enablePublicDashboard(args[0])
+	},
+}
+
+func init() {
+	publicDashboardCmd.AddCommand(publicDashboardEnableCmd)
+}
+
+func enablePublicDashboard(dashboardUID string) {
+	c := newClient()
+	public, err := c.GetPublicDashboard(dashboardUID)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if public.UID == "" {
+		_, err = c.CreatePublicDashboard(dashboardUID, true)
+	} else {
+		_, err = c.UpdatePublicDashboard(dashboardUID, public.UID, true)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("Dashboard %s is shared publicly\n", dashboardUID)
+}
@@ -0,0 +1,43 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"log"
+
+	"github.com/spf13/cobra"
+)
+
+// publicDashboardListCmd represents the public-dashboard list command
+var publicDashboardListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists publicly shared dashboards",
+	Run: func(cmd *cobra.Command, args []string) {
+		listPublicDashboards()
+	},
+}
+
+func init() {
+	publicDashboardCmd.AddCommand(publicDashboardListCmd)
+}
+
+func listPublicDashboards() {
+	c := newClient()
+	publicDashboards, err := c.GetPublicDashboards()
+	if err != nil {
+		log.Fatal(err)
+	}
+	printList(publicDashboardHeader, publicDashboardRows(publicDashboards), nil, 0)
+}
@@ -16,8 +16,12 @@ package cmd
 
 import (
 	"fmt"
+	"log"
+	"net/http"
 	"os"
+	"strings"
 
+	"github.com/lstuker/grafana-tool/grafana"
 	homedir "github.com/mitchellh/go-homedir"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -53,6 +57,7 @@ func init() {
 	rootCmd.PersistentFlags().StringVarP(&grafanaURL, "grafana-url", "u", viper.GetString("GRAFANA_URL"), "set the url to grafana (or use env GRAFANA_URL)")
 	rootCmd.PersistentFlags().StringVar(&username, "user", "", "grafana user")
 	rootCmd.PersistentFlags().StringVar(&password, "password", "", "grafana user password")
+	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "table", "output format of list commands: "+strings.Join(outputFormats, ", "))
 	rootCmd.PersistentFlags().StringVarP(&apiToken, "api-token", "t", viper.GetString("GRAFANA_API_TOKEN"), "grafana api token (or use env GRAFANA_API_TOKEN)")
 
 	// Here you will define your flags and configuration settings.
@@ -81,8 +86,36 @@ func initConfig() {
 
 	viper.AutomaticEnv() // read in environment variables that match
 
-	// If a config file is found, read it in.
+	// If a config file is found, read it in. The message goes to stderr to
+	// keep the output of list commands parsable.
 	if err := viper.ReadInConfig(); err == nil {
-		fmt.Println("Using config file:", viper.ConfigFileUsed())
+		fmt.Fprintln(os.Stderr, "Using config file:", viper.ConfigFileUsed())
 	}
+
+	if !validOutputFormat(outputFormat) {
+		fmt.Fprintf(os.Stderr, "Invalid output format %s, use one of: %s\n", outputFormat, strings.Join(outputFormats, ", "))
+		os.Exit(1)
+	}
+}
+
+// newClient returns a Grafana client for the instance given by the global flags.
+func newClient() *grafana.Client {
+	return grafana.NewClient(grafanaURL, apiToken, username, password, http.DefaultClient)
+}
+
+// profileClient returns a Grafana client for a profile of the config file.
+// A profile has the keys grafana-url, api-token, user and password below
+// profiles.<name>.
+func profileClient(name string) *grafana.Client {
+	key := "profiles." + name
+	if !viper.IsSet(key) {
+		log.Fatalf("Profile %s not found in the config file", name)
+	}
+	return grafana.NewClient(
+		viper.GetString(key+".grafana-url"),
+		viper.GetString(key+".api-token"),
+		viper.GetString(key+".user"),
+		viper.GetString(key+".password"),
+		http.DefaultClient,
+	)
 }
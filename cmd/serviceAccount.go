@@ -0,0 +1,46 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"log"
+
+	"github.com/lstuker/grafana-tool/grafana"
+	"github.com/spf13/cobra"
+)
+
+// serviceAccountCmd represents the service-account command
+var serviceAccountCmd = &cobra.Command{
+	Use:   "service-account",
+	Short: "Manage Grafana service accounts",
+	Long:  `Manage Grafana service accounts`,
+}
+
+func init() {
+	rootCmd.AddCommand(serviceAccountCmd)
+}
+
+// findServiceAccount returns the service account with the given name or ID or exits
+func findServiceAccount(c *grafana.Client, nameOrID string) grafana.ServiceAccountJSON {
+	accounts, err := c.GetServiceAccounts()
+	if err != nil {
+		log.Fatal(err)
+	}
+	account, err := accounts.ServiceAccountFind(nameOrID)
+	if err != nil {
+		log.Fatalf("%s: %s", err, nameOrID)
+	}
+	return account
+}
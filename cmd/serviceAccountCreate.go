@@ -0,0 +1,52 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"log"
+
+	"github.com/lstuker/grafana-tool/grafana"
+	"github.com/spf13/cobra"
+)
+
+var serviceAccountRole string
+
+// serviceAccountCreateCmd represents the service-account create command
+var serviceAccountCreateCmd = &cobra.Command{
+	Use:   "create NAME",
+	Short: "Creates a service account",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		createServiceAccount(args[0])
+	},
+}
+
+func init() {
+	serviceAccountCmd.AddCommand(serviceAccountCreateCmd)
+	serviceAccountCreateCmd.Flags().StringVar(&serviceAccountRole, "role", "Viewer", "Role of the service account: Viewer, Editor or Admin")
+}
+
+func createServiceAccount(name string) {
+	if !grafana.ValidRole(serviceAccountRole) {
+		log.Fatalf("Invalid role %s, use Viewer, Editor or Admin", serviceAccountRole)
+	}
+
+	c := newClient()
+	account, err := c.CreateServiceAccount(name, serviceAccountRole)
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("Created service account %s with ID %d\n", account.Name, account.ID)
+}
@@ -0,0 +1,45 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"log"
+
+	"github.com/spf13/cobra"
+)
+
+// serviceAccountDeleteCmd represents the service-account delete command
+var serviceAccountDeleteCmd = &cobra.Command{
+	Use:   "delete NAME_OR_ID",
+	Short: "Deletes a service account and all its tokens",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		deleteServiceAccount(args[0])
+	},
+}
+
+func init() {
+	serviceAccountCmd.AddCommand(serviceAccountDeleteCmd)
+}
+
+func deleteServiceAccount(nameOrID string) {
+	c := newClient()
+	account := findServiceAccount(c, nameOrID)
+	err := c.DeleteServiceAccount(account.ID)
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("Deleted service account %s\n", account.Name)
+}
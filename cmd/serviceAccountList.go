@@ -0,0 +1,55 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"log"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+// serviceAccountListCmd represents the service-account list command
+var serviceAccountListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists service accounts",
+	Run: func(cmd *cobra.Command, args []string) {
+		listServiceAccounts()
+	},
+}
+
+func init() {
+	serviceAccountCmd.AddCommand(serviceAccountListCmd)
+}
+
+func listServiceAccounts() {
+	c := newClient()
+	accounts, err := c.GetServiceAccounts()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	rows := [][]string{}
+	for _, account := range accounts {
+		rows = append(rows, []string{
+			strconv.Itoa(account.ID),
+			account.Name,
+			account.Role,
+			strconv.Itoa(account.Tokens),
+			strconv.FormatBool(account.IsDisabled),
+		})
+	}
+	printList([]string{"ID", "NAME", "ROLE", "TOKENS", "DISABLED"}, rows, accounts, 0)
+}
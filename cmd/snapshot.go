@@ -0,0 +1,50 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"strconv"
+
+	"github.com/lstuker/grafana-tool/grafana"
+	"github.com/spf13/cobra"
+)
+
+// snapshotCmd represents the snapshot command
+var snapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Manage Grafana dashboard snapshots",
+	Long:  `Manage Grafana dashboard snapshots`,
+}
+
+func init() {
+	rootCmd.AddCommand(snapshotCmd)
+}
+
+var snapshotHeader = []string{"KEY", "NAME", "USER ID", "CREATED", "EXPIRES"}
+
+// snapshotRows returns the table rows for a list of snapshots
+func snapshotRows(snapshots grafana.SnapshotListJSON) [][]string {
+	rows := [][]string{}
+	for _, snapshot := range snapshots {
+		rows = append(rows, []string{
+			snapshot.Key,
+			snapshot.Name,
+			strconv.Itoa(snapshot.UserID),
+			snapshot.Created.Format("2006-01-02"),
+			formatOptionalTime(&snapshot.Expires, "never"),
+		})
+	}
+	return rows
+}
@@ -0,0 +1,66 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/lstuker/grafana-tool/grafana"
+	"github.com/spf13/cobra"
+)
+
+var snapshotName string
+var snapshotExpires string
+
+// snapshotCreateCmd represents the snapshot create command
+var snapshotCreateCmd = &cobra.Command{
+	Use:   "create FILE",
+	Short: "Creates a snapshot of a dashboard JSON file",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		createSnapshot(args[0])
+	},
+}
+
+func init() {
+	snapshotCmd.AddCommand(snapshotCreateCmd)
+	snapshotCreateCmd.Flags().StringVar(&snapshotName, "name", "", "Name of the snapshot (default is the dashboard title)")
+	snapshotCreateCmd.Flags().StringVar(&snapshotExpires, "expires", "", "Lifetime of the snapshot, ex: 7d (default never expires)")
+}
+
+func createSnapshot(file string) {
+	dashboard, err := readDashboardFile(file)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var expires time.Duration
+	if snapshotExpires != "" {
+		expires, err = grafana.ParseDuration(snapshotExpires)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	c := newClient()
+	snapshot, err := c.CreateSnapshot(dashboard, snapshotName, expires)
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("Created snapshot %s\n", snapshot.Key)
+	fmt.Println(snapshot.URL)
+}
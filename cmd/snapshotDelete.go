@@ -0,0 +1,46 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"log"
+
+	"github.com/spf13/cobra"
+)
+
+// snapshotDeleteCmd represents the snapshot delete command
+var snapshotDeleteCmd = &cobra.Command{
+	Use:   "delete KEY...",
+	Short: "Deletes snapshots",
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		deleteSnapshots(args)
+	},
+}
+
+func init() {
+	snapshotCmd.AddCommand(snapshotDeleteCmd)
+}
+
+func deleteSnapshots(keys []string) {
+	c := newClient()
+	for _, key := range keys {
+		err := c.DeleteSnapshot(key)
+		if err != nil {
+			log.Fatal(err)
+		}
+		log.Printf("Deleted snapshot %s\n", key)
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"log"
+	"time"
+
+	"github.com/lstuker/grafana-tool/grafana"
+	"github.com/spf13/cobra"
+)
+
+var snapshotOlderThan string
+var snapshotDepartedUsers bool
+var snapshotDryRun bool
+
+// snapshotPruneCmd represents the snapshot prune command
+var snapshotPruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Deletes old snapshots and snapshots of departed users",
+	Long: `Deletes snapshots older than --older-than and, with --departed-users,
+snapshots created by users which are deleted or disabled. Snapshots created
+with API keys, anonymously or by service accounts are kept. Finding departed
+users needs Grafana admin permissions.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		pruneSnapshots()
+	},
+}
+
+func init() {
+	snapshotCmd.AddCommand(snapshotPruneCmd)
+	snapshotPruneCmd.Flags().StringVar(&snapshotOlderThan, "older-than", "", "Delete snapshots older than this, ex: 90d")
+	snapshotPruneCmd.Flags().BoolVar(&snapshotDepartedUsers, "departed-users", false, "Delete snapshots created by deleted or disabled users")
+	snapshotPruneCmd.Flags().BoolVar(&snapshotDryRun, "dry-run", false, "Only show the snapshots that would be deleted")
+}
+
+func pruneSnapshots() {
+	if snapshotOlderThan == "" && !snapshotDepartedUsers {
+		log.Fatal("Nothing to prune, use --older-than or --departed-users")
+	}
+
+	c := newClient()
+	snapshots, err := c.GetSnapshots()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	prune := grafana.SnapshotListJSON{}
+	if snapshotOlderThan != "" {
+		age, err := grafana.ParseDuration(snapshotOlderThan)
+		if err != nil {
+			log.Fatal(err)
+		}
+		prune = append(prune, snapshots.CreatedBefore(time.Now().Add(-age))...)
+	}
+	if snapshotDepartedUsers {
+		users, err := c.GetUsers()
+		if err != nil {
+			log.Fatal(err)
+		}
+		serviceAccounts, err := c.GetServiceAccounts()
+		if err != nil {
+			log.Fatal(err)
+		}
+		deleted := []int{}
+		for _, ID := range snapshots.UnknownCreators(users, serviceAccounts) {
+			exists, err := c.UserExists(ID)
+			if err != nil {
+				log.Fatal(err)
+			}
+			if !exists {
+				deleted = append(deleted, ID)
+			}
+		}
+		for _, snapshot := range snapshots.CreatedByDeparted(users, serviceAccounts, deleted) {
+			duplicate := false
+			for _, other := range prune {
+				duplicate = duplicate || other.Key == snapshot.Key
+			}
+			if !duplicate {
+				prune = append(prune, snapshot)
+			}
+		}
+	}
+
+	printTable(snapshotHeader, snapshotRows(prune))
+	if snapshotDryRun {
+		log.Printf("Dry run: %d snapshots would be deleted\n", len(prune))
+		return
+	}
+
+	for _, snapshot := range prune {
+		err = c.DeleteSnapshot(snapshot.Key)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+	log.Printf("Deleted %d snapshots\n", len(prune))
+}
@@ -0,0 +1,65 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"log"
+
+	"github.com/lstuker/grafana-tool/grafana"
+	"github.com/spf13/cobra"
+)
+
+// teamCmd represents the team command
+var teamCmd = &cobra.Command{
+	Use:   "team",
+	Short: "Manage Grafana teams and their members",
+	Long:  `Manage Grafana teams and their members`,
+}
+
+func init() {
+	rootCmd.AddCommand(teamCmd)
+	teamCmd.PersistentFlags().IntVar(&userOrgID, "org-id", 0, "Organisation ID of the teams and users (default is the current organisation)")
+}
+
+// teamClient returns a client for the organisation given with --org-id
+func teamClient() *grafana.Client {
+	return newClient().WithOrg(userOrgID)
+}
+
+// findTeam returns the team with the given name or exits
+func findTeam(c *grafana.Client, name string) grafana.TeamJSON {
+	teams, err := c.GetTeams()
+	if err != nil {
+		log.Fatal(err)
+	}
+	team, err := teams.TeamFindByName(name)
+	if err != nil {
+		log.Fatalf("%s: %s", err, name)
+	}
+	return team
+}
+
+// findOrgUser returns the organisation user with the given login or email or exits
+func findOrgUser(c *grafana.Client, loginOrEmail string) grafana.OrgUserJSON {
+	users, err := c.GetOrgUsers(currentOrgID(c))
+	if err != nil {
+		log.Fatal(err)
+	}
+	user, err := users.FindByLoginOrEmail(loginOrEmail)
+	if err != nil {
+		log.Fatalf("%s: %s", err, loginOrEmail)
+	}
+	return user
+}
@@ -0,0 +1,46 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"log"
+
+	"github.com/spf13/cobra"
+)
+
+// teamAddMemberCmd represents the team add-member command
+var teamAddMemberCmd = &cobra.Command{
+	Use:   "add-member TEAM LOGIN_OR_EMAIL",
+	Short: "Adds a user to a team",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		addTeamMember(args[0], args[1])
+	},
+}
+
+func init() {
+	teamCmd.AddCommand(teamAddMemberCmd)
+}
+
+func addTeamMember(teamName string, loginOrEmail string) {
+	c := teamClient()
+	team := findTeam(c, teamName)
+	user := findOrgUser(c, loginOrEmail)
+	err := c.AddTeamMember(team.ID, user.UserID)
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("Added %s to team %s\n", user.Login, team.Name)
+}
@@ -0,0 +1,45 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"log"
+
+	"github.com/spf13/cobra"
+)
+
+// teamDeleteCmd represents the team delete command
+var teamDeleteCmd = &cobra.Command{
+	Use:   "delete NAME",
+	Short: "Deletes a team",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		deleteTeam(args[0])
+	},
+}
+
+func init() {
+	teamCmd.AddCommand(teamDeleteCmd)
+}
+
+func deleteTeam(name string) {
+	c := teamClient()
+	team := findTeam(c, name)
+	err := c.DeleteTeam(team.ID)
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("Deleted team %s\n", name)
+}
@@ -0,0 +1,136 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"io/ioutil"
+	"log"
+
+	"github.com/lstuker/grafana-tool/grafana"
+	"github.com/spf13/cobra"
+	yaml "gopkg.in/yaml.v2"
+)
+
+var teamImportPath string
+var teamImportDryRun bool
+
+// teamFile is the desired state of teams read by team import
+type teamFile struct {
+	Teams []struct {
+		Name    string   `yaml:"name"`
+		Email   string   `yaml:"email"`
+		Members *[]string `yaml:"members"`
+	} `yaml:"teams"`
+}
+
+// teamImportCmd represents the team import command
+var teamImportCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Creates teams and reconciles their members to a YAML file",
+	Long: `Creates the teams of a YAML file if they are missing and reconciles their
+members: users in the file are added, members not in the file are removed.
+The members of a team without the members key are not changed.
+
+Example file:
+
+  teams:
+    - name: devops
+      email: devops@example.com
+      members:
+        - alice
+        - bob@example.com`,
+	Run: func(cmd *cobra.Command, args []string) {
+		importTeams()
+	},
+}
+
+func init() {
+	teamCmd.AddCommand(teamImportCmd)
+	teamImportCmd.Flags().StringVarP(&teamImportPath, "path", "p", "", "YAML file with the desired teams (required)")
+	teamImportCmd.MarkFlagRequired("path")
+	teamImportCmd.Flags().BoolVar(&teamImportDryRun, "dry-run", false, "Only show the changes")
+}
+
+func importTeams() {
+	data, err := ioutil.ReadFile(teamImportPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var desired teamFile
+	err = yaml.Unmarshal(data, &desired)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	c := teamClient()
+	teams, err := c.GetTeams()
+	if err != nil {
+		log.Fatal(err)
+	}
+	users, err := c.GetOrgUsers(currentOrgID(c))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, want := range desired.Teams {
+		team, err := teams.TeamFindByName(want.Name)
+		exists := err == nil
+		if !exists {
+			log.Printf("Create team %s\n", want.Name)
+			if !teamImportDryRun {
+				team.ID, err = c.CreateTeam(want.Name, want.Email)
+				if err != nil {
+					log.Fatal(err)
+				}
+			}
+		}
+		// teams without the members key keep their members
+		if want.Members == nil {
+			continue
+		}
+		members := grafana.TeamMemberListJSON{}
+		if exists {
+			members, err = c.GetTeamMembers(team.ID)
+			if err != nil {
+				log.Fatal(err)
+			}
+		}
+
+		add, remove := members.MembershipChanges(*want.Members)
+		for _, loginOrEmail := range add {
+			user, err := users.FindByLoginOrEmail(loginOrEmail)
+			if err != nil {
+				log.Printf("Skip %s in team %s: user is not a member of the organisation\n", loginOrEmail, want.Name)
+				continue
+			}
+			log.Printf("Add %s to team %s\n", user.Login, want.Name)
+			if !teamImportDryRun {
+				err = c.AddTeamMember(team.ID, user.UserID)
+				if err != nil {
+					log.Fatal(err)
+				}
+			}
+		}
+		for _, member := range remove {
+			log.Printf("Remove %s from team %s\n", member.Login, want.Name)
+			if !teamImportDryRun {
+				err = c.RemoveTeamMember(team.ID, member.UserID)
+				if err != nil {
+					log.Fatal(err)
+				}
+			}
+		}
+	}
+}
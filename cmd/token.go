@@ -0,0 +1,95 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/lstuker/grafana-tool/grafana"
+	"github.com/spf13/cobra"
+)
+
+var tokenServiceAccount string
+var tokenTTL string
+var tokenKeyFile string
+
+// tokenCmd represents the token command
+var tokenCmd = &cobra.Command{
+	Use:   "token",
+	Short: "Manage tokens of Grafana service accounts",
+	Long:  `Manage tokens of Grafana service accounts`,
+}
+
+func init() {
+	rootCmd.AddCommand(tokenCmd)
+	tokenCmd.PersistentFlags().StringVarP(&tokenServiceAccount, "service-account", "s", "", "Name or ID of the service account")
+}
+
+// addTokenKeyFlags adds the flags of commands creating a new token
+func addTokenKeyFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&tokenTTL, "ttl", "", "Lifetime of the token, ex: 90d (default never expires)")
+	cmd.Flags().StringVar(&tokenKeyFile, "key-file", "", "Write the token key to this file with 0600 permissions instead of stdout")
+}
+
+// tokenSecondsToLive returns the --ttl flag in seconds
+func tokenSecondsToLive() int {
+	if tokenTTL == "" {
+		return 0
+	}
+	ttl, err := grafana.ParseDuration(tokenTTL)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return int(ttl.Seconds())
+}
+
+// requireServiceAccount returns the service account given by --service-account or exits
+func requireServiceAccount(c *grafana.Client) grafana.ServiceAccountJSON {
+	if tokenServiceAccount == "" {
+		log.Fatal("Missing --service-account")
+	}
+	return findServiceAccount(c, tokenServiceAccount)
+}
+
+// writeTokenKey writes a new token key to --key-file or stdout
+func writeTokenKey(key string) {
+	if tokenKeyFile == "" {
+		fmt.Println(key)
+		return
+	}
+	writeSecretFile(tokenKeyFile, key)
+}
+
+// writeSecretFile writes a secret to a file only readable by the user
+func writeSecretFile(file string, secret string) {
+	f, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		log.Fatal(err)
+	}
+	// an existing file keeps its permissions on open
+	err = f.Chmod(0600)
+	if err == nil {
+		_, err = f.WriteString(secret + "\n")
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("Wrote token key to: %s\n", file)
+}
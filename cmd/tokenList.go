@@ -0,0 +1,105 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/lstuker/grafana-tool/grafana"
+	"github.com/spf13/cobra"
+)
+
+var tokenExpiringWithin int
+var tokenNeverUsed bool
+
+// tokenListCmd represents the token list command
+var tokenListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists tokens of one or all service accounts",
+	Run: func(cmd *cobra.Command, args []string) {
+		listTokens()
+	},
+}
+
+func init() {
+	tokenCmd.AddCommand(tokenListCmd)
+	tokenListCmd.Flags().IntVar(&tokenExpiringWithin, "expiring-within", 0, "Only tokens expiring within this number of days or already expired")
+	tokenListCmd.Flags().BoolVar(&tokenNeverUsed, "never-used", false, "Only tokens which were never used")
+}
+
+func listTokens() {
+	c := newClient()
+	accounts := grafana.ServiceAccountListJSON{}
+	if tokenServiceAccount != "" {
+		accounts = append(accounts, findServiceAccount(c, tokenServiceAccount))
+	} else {
+		var err error
+		accounts, err = c.GetServiceAccounts()
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	now := time.Now()
+	within := time.Duration(tokenExpiringWithin) * 24 * time.Hour
+	if tokenExpiringWithin > 0 {
+		log.Printf("Tokens expiring within %d days, already expired tokens included\n", tokenExpiringWithin)
+	}
+	rows := [][]string{}
+	for _, account := range accounts {
+		tokens, err := c.GetServiceAccountTokens(account.ID)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, token := range tokens {
+			if tokenExpiringWithin > 0 && !token.ExpiresWithin(now, within) {
+				continue
+			}
+			if tokenNeverUsed && !token.NeverUsed() {
+				continue
+			}
+			rows = append(rows, []string{
+				account.Name,
+				strconv.Itoa(token.ID),
+				token.Name,
+				token.Created.Format("2006-01-02"),
+				formatOptionalTime(token.Expiration, "never"),
+				formatOptionalTime(token.LastUsedAt, "never"),
+				tokenStatus(token),
+			})
+		}
+	}
+	printList([]string{"SERVICE ACCOUNT", "ID", "NAME", "CREATED", "EXPIRES", "LAST USED", "STATUS"}, rows, nil, 2)
+}
+
+func tokenStatus(token grafana.ServiceAccountTokenJSON) string {
+	if token.IsRevoked {
+		return "revoked"
+	}
+	if token.HasExpired {
+		return "expired"
+	}
+	return "active"
+}
+
+// formatOptionalTime formats a time which Grafana returns as null when unset
+func formatOptionalTime(t *time.Time, empty string) string {
+	if t == nil || t.IsZero() {
+		return empty
+	}
+	return t.Format("2006-01-02")
+}
@@ -0,0 +1,53 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"log"
+
+	"github.com/spf13/cobra"
+)
+
+// tokenRevokeCmd represents the token revoke command
+var tokenRevokeCmd = &cobra.Command{
+	Use:   "revoke NAME_OR_ID",
+	Short: "Revokes a token of a service account",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		revokeToken(args[0])
+	},
+}
+
+func init() {
+	tokenCmd.AddCommand(tokenRevokeCmd)
+}
+
+func revokeToken(nameOrID string) {
+	c := newClient()
+	account := requireServiceAccount(c)
+	tokens, err := c.GetServiceAccountTokens(account.ID)
+	if err != nil {
+		log.Fatal(err)
+	}
+	token, err := tokens.TokenFind(nameOrID)
+	if err != nil {
+		log.Fatalf("%s: %s", err, nameOrID)
+	}
+	err = c.DeleteServiceAccountToken(account.ID, token.ID)
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("Revoked token %s of service account %s\n", token.Name, account.Name)
+}
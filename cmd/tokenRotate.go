@@ -0,0 +1,72 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"log"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var tokenNewName string
+
+// tokenRotateCmd represents the token rotate command
+var tokenRotateCmd = &cobra.Command{
+	Use:   "rotate NAME_OR_ID",
+	Short: "Replaces a token of a service account with a new one",
+	Long: `Creates a new token for the service account and revokes the old one.
+The new token is named after the old one with a timestamp suffix.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		rotateToken(args[0])
+	},
+}
+
+func init() {
+	tokenCmd.AddCommand(tokenRotateCmd)
+	addTokenKeyFlags(tokenRotateCmd)
+	tokenRotateCmd.Flags().StringVar(&tokenNewName, "name", "", "Name of the new token")
+}
+
+func rotateToken(nameOrID string) {
+	c := newClient()
+	account := requireServiceAccount(c)
+	tokens, err := c.GetServiceAccountTokens(account.ID)
+	if err != nil {
+		log.Fatal(err)
+	}
+	old, err := tokens.TokenFind(nameOrID)
+	if err != nil {
+		log.Fatalf("%s: %s", err, nameOrID)
+	}
+
+	name := tokenNewName
+	if name == "" {
+		name = old.Name + "-" + time.Now().Format("20060102150405")
+	}
+	token, err := c.CreateServiceAccountToken(account.ID, name, tokenSecondsToLive())
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("Created token %s for service account %s\n", token.Name, account.Name)
+	writeTokenKey(token.Key)
+
+	err = c.DeleteServiceAccountToken(account.ID, old.ID)
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("Revoked token %s of service account %s\n", old.Name, account.Name)
+}
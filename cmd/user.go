@@ -0,0 +1,48 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"log"
+
+	"github.com/lstuker/grafana-tool/grafana"
+	"github.com/spf13/cobra"
+)
+
+var userOrgID int
+
+// userCmd represents the user command
+var userCmd = &cobra.Command{
+	Use:   "user",
+	Short: "Manage Grafana users",
+	Long:  `Manage Grafana users`,
+}
+
+func init() {
+	rootCmd.AddCommand(userCmd)
+	userCmd.PersistentFlags().IntVar(&userOrgID, "org-id", 0, "Organisation ID (default is the current organisation)")
+}
+
+// currentOrgID returns the organisation given by --org-id or the current one
+func currentOrgID(c *grafana.Client) int {
+	if userOrgID != 0 {
+		return userOrgID
+	}
+	org, err := c.GetCurrentOrg()
+	if err != nil {
+		log.Fatal(err)
+	}
+	return org.ID
+}
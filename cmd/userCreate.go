@@ -0,0 +1,63 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"log"
+
+	"github.com/lstuker/grafana-tool/grafana"
+	"github.com/spf13/cobra"
+)
+
+var newUser grafana.NewUserJSON
+var newUserRole string
+
+// userCreateCmd represents the user create command
+var userCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Creates a user, needs Grafana admin permissions",
+	Run: func(cmd *cobra.Command, args []string) {
+		createUser()
+	},
+}
+
+func init() {
+	userCmd.AddCommand(userCreateCmd)
+	userCreateCmd.Flags().StringVar(&newUser.Login, "login", "", "Login of the user (required)")
+	userCreateCmd.MarkFlagRequired("login")
+	userCreateCmd.Flags().StringVar(&newUser.Email, "email", "", "Email of the user")
+	userCreateCmd.Flags().StringVar(&newUser.Name, "name", "", "Name of the user")
+	userCreateCmd.Flags().StringVar(&newUser.Password, "new-password", "", "Password of the user (required)")
+	userCreateCmd.MarkFlagRequired("new-password")
+	userCreateCmd.Flags().StringVar(&newUserRole, "role", "", "Role in the organisation: Viewer, Editor or Admin")
+}
+
+func createUser() {
+	c := newClient()
+	newUser.OrgID = userOrgID
+	id, err := c.CreateUser(newUser)
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("Created user %s with ID %d\n", newUser.Login, id)
+
+	if newUserRole != "" {
+		err = c.UpdateOrgUserRole(currentOrgID(c), id, newUserRole)
+		if err != nil {
+			log.Fatal(err)
+		}
+		log.Printf("Set role of %s to %s\n", newUser.Login, newUserRole)
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"log"
+
+	"github.com/spf13/cobra"
+)
+
+// userDeleteCmd represents the user delete command
+var userDeleteCmd = &cobra.Command{
+	Use:   "delete LOGIN_OR_EMAIL",
+	Short: "Deletes a user, needs Grafana admin permissions",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		deleteUser(args[0])
+	},
+}
+
+func init() {
+	userCmd.AddCommand(userDeleteCmd)
+}
+
+func deleteUser(loginOrEmail string) {
+	c := newClient()
+	user, err := c.GetUserByLoginOrEmail(loginOrEmail)
+	if err != nil {
+		log.Fatal(err)
+	}
+	err = c.DeleteUser(user.ID)
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("Deleted user %s\n", user.Login)
+}
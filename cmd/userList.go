@@ -0,0 +1,49 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"log"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+// userListCmd represents the user list command
+var userListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists the users of an organisation with their role",
+	Run: func(cmd *cobra.Command, args []string) {
+		listUsers()
+	},
+}
+
+func init() {
+	userCmd.AddCommand(userListCmd)
+}
+
+func listUsers() {
+	c := newClient()
+	users, err := c.GetOrgUsers(currentOrgID(c))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	rows := [][]string{}
+	for _, user := range users {
+		rows = append(rows, []string{strconv.Itoa(user.UserID), user.Login, user.Email, user.Name, user.Role})
+	}
+	printList([]string{"ID", "LOGIN", "EMAIL", "NAME", "ROLE"}, rows, users, 0)
+}
@@ -0,0 +1,53 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"log"
+
+	"github.com/spf13/cobra"
+)
+
+// userSetRoleCmd represents the user set-role command
+var userSetRoleCmd = &cobra.Command{
+	Use:   "set-role LOGIN_OR_EMAIL ROLE",
+	Short: "Sets the role (Viewer, Editor or Admin) of a user in the organisation",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		setUserRole(args[0], args[1])
+	},
+}
+
+func init() {
+	userCmd.AddCommand(userSetRoleCmd)
+}
+
+func setUserRole(loginOrEmail string, role string) {
+	c := newClient()
+	orgID := currentOrgID(c)
+	users, err := c.GetOrgUsers(orgID)
+	if err != nil {
+		log.Fatal(err)
+	}
+	user, err := users.FindByLoginOrEmail(loginOrEmail)
+	if err != nil {
+		log.Fatalf("%s: %s is not a member of organisation %d", err, loginOrEmail, orgID)
+	}
+	err = c.UpdateOrgUserRole(orgID, user.UserID, role)
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("Set role of %s to %s\n", user.Login, role)
+}
@@ -0,0 +1,164 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grafana
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// AnnotationListJSON is a list of annotations from the Grafana API
+// More info: https://grafana.com/docs/http_api/annotations/
+type AnnotationListJSON []AnnotationJSON
+
+// AnnotationJSON is an annotation from the Grafana API
+// More info: https://grafana.com/docs/http_api/annotations/
+type AnnotationJSON struct {
+	ID           int             `json:"id,omitempty"`
+	AlertID      int             `json:"alertId,omitempty"`
+	DashboardID  int             `json:"dashboardId,omitempty"`
+	DashboardUID string          `json:"dashboardUID,omitempty"`
+	PanelID      int             `json:"panelId,omitempty"`
+	UserID       int             `json:"userId,omitempty"`
+	NewState     string          `json:"newState,omitempty"`
+	PrevState    string          `json:"prevState,omitempty"`
+	Created      int64           `json:"created,omitempty"`
+	Updated      int64           `json:"updated,omitempty"`
+	Time         int64           `json:"time"`
+	TimeEnd      int64           `json:"timeEnd,omitempty"`
+	Text         string          `json:"text"`
+	Tags         []string        `json:"tags"`
+	Login        string          `json:"login,omitempty"`
+	Email        string          `json:"email,omitempty"`
+	Data         json.RawMessage `json:"data,omitempty"`
+}
+
+// AnnotationQuery filters the annotations returned by GetAnnotations.
+// Zero values are not sent to Grafana.
+type AnnotationQuery struct {
+	DashboardUID string
+	PanelID      int
+	Tags         []string
+	From         time.Time
+	To           time.Time
+	Type         string
+	Limit        int
+}
+
+// GetAnnotations returns the annotations matching the query.
+// It reflects GET /api/annotations API call.
+// More info: https://grafana.com/docs/http_api/annotations/
+func (r *Client) GetAnnotations(query AnnotationQuery) (AnnotationListJSON, error) {
+	var (
+		records AnnotationListJSON
+		raw     []byte
+		code    int
+		err     error
+	)
+
+	q := url.Values{}
+	if query.DashboardUID != "" {
+		q.Set("dashboardUID", query.DashboardUID)
+	}
+	if query.PanelID != 0 {
+		q.Set("panelId", strconv.Itoa(query.PanelID))
+	}
+	for _, tag := range query.Tags {
+		q.Add("tags", tag)
+	}
+	if !query.From.IsZero() {
+		q.Set("from", strconv.FormatInt(EpochMillis(query.From), 10))
+	}
+	if !query.To.IsZero() {
+		q.Set("to", strconv.FormatInt(EpochMillis(query.To), 10))
+	}
+	if query.Type != "" {
+		q.Set("type", query.Type)
+	}
+	if query.Limit != 0 {
+		q.Set("limit", strconv.Itoa(query.Limit))
+	}
+
+	raw, code, err = r.getRequest("/api/annotations", q)
+
+	if err != nil && code != 200 {
+		return records, err
+	}
+	if code != 200 {
+		return nil, fmt.Errorf("HTTP error %d: returns %s", code, raw)
+	}
+
+	err = json.Unmarshal(raw, &records)
+	return records, err
+}
+
+// CreateAnnotation creates an annotation and returns its new ID. Instance
+// specific fields like ID, DashboardID and UserID are not sent, so an
+// exported annotation can be created on another Grafana.
+// It reflects POST /api/annotations API call.
+// More info: https://grafana.com/docs/http_api/annotations/
+func (r *Client) CreateAnnotation(annotation AnnotationJSON) (int, error) {
+	var (
+		raw  []byte
+		code int
+		err  error
+	)
+
+	body, err := json.Marshal(AnnotationJSON{
+		DashboardUID: annotation.DashboardUID,
+		PanelID:      annotation.PanelID,
+		Time:         annotation.Time,
+		TimeEnd:      annotation.TimeEnd,
+		Tags:         annotation.Tags,
+		Text:         annotation.Text,
+		Data:         annotation.Data,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	raw, code, err = r.postRequest("/api/annotations", nil, body)
+
+	if err != nil && code != 200 {
+		return 0, err
+	}
+	if code != 200 {
+		return 0, fmt.Errorf("HTTP error %d: returns %s", code, raw)
+	}
+
+	result := struct {
+		ID int `json:"id"`
+	}{}
+	err = json.Unmarshal(raw, &result)
+	return result.ID, err
+}
+
+// DeleteAnnotation deletes the annotation with the given ID.
+// It reflects DELETE /api/annotations/:id API call.
+// More info: https://grafana.com/docs/http_api/annotations/
+func (r *Client) DeleteAnnotation(ID int) error {
+	raw, code, err := r.deleteRequest(fmt.Sprintf("/api/annotations/%d", ID))
+
+	if err != nil && code != 200 {
+		return err
+	}
+	if code != 200 {
+		return fmt.Errorf("HTTP error %d: returns %s", code, raw)
+	}
+	return nil
+}
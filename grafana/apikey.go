@@ -0,0 +1,117 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grafana
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// APIKeyListJSON is a list of legacy API keys from the Grafana API
+// More info: https://grafana.com/docs/http_api/auth/
+type APIKeyListJSON []APIKeyJSON
+
+// APIKeyJSON is a legacy API key from the Grafana API. Older Grafana
+// versions do not return when a key was created or last used.
+// More info: https://grafana.com/docs/http_api/auth/
+type APIKeyJSON struct {
+	ID         int        `json:"id"`
+	Name       string     `json:"name"`
+	Role       string     `json:"role"`
+	Created    *time.Time `json:"created,omitempty"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+	Expiration *time.Time `json:"expiration,omitempty"`
+}
+
+// GetAPIKeys returns all legacy API keys of the organisation including expired ones.
+// It reflects GET /api/auth/keys API call.
+// More info: https://grafana.com/docs/http_api/auth/
+func (r *Client) GetAPIKeys() (APIKeyListJSON, error) {
+	var (
+		records APIKeyListJSON
+		raw     []byte
+		code    int
+		err     error
+	)
+
+	q := url.Values{}
+	q.Set("includeExpired", "true")
+
+	raw, code, err = r.getRequest("/api/auth/keys", q)
+
+	if err != nil && code != 200 {
+		return records, err
+	}
+	if code != 200 {
+		return nil, fmt.Errorf("HTTP error %d: returns %s", code, raw)
+	}
+
+	err = json.Unmarshal(raw, &records)
+	return records, err
+}
+
+// DeleteAPIKey deletes the legacy API key with the given ID.
+// It reflects DELETE /api/auth/keys/:id API call.
+// More info: https://grafana.com/docs/http_api/auth/
+func (r *Client) DeleteAPIKey(ID int) error {
+	raw, code, err := r.deleteRequest(fmt.Sprintf("/api/auth/keys/%d", ID))
+
+	if err != nil && code != 200 {
+		return err
+	}
+	if code != 200 {
+		return fmt.Errorf("HTTP error %d: returns %s", code, raw)
+	}
+	return nil
+}
+
+// ErrMigrationNotSupported is returned when Grafana has no API key migration endpoint
+var ErrMigrationNotSupported = errors.New("Grafana does not support the API key migration")
+
+// MigrateAPIKey converts a legacy API key into a service account with a
+// token, the key keeps working as token of the new service account.
+// It reflects POST /api/serviceaccounts/migrate/:keyId API call.
+// More info: https://grafana.com/docs/http_api/serviceaccount/
+func (r *Client) MigrateAPIKey(ID int) error {
+	raw, code, err := r.postRequest(fmt.Sprintf("/api/serviceaccounts/migrate/%d", ID), nil, nil)
+
+	if err != nil && code != 200 {
+		return err
+	}
+	if code == 404 {
+		return ErrMigrationNotSupported
+	}
+	if code != 200 {
+		return fmt.Errorf("HTTP error %d: returns %s", code, raw)
+	}
+	return nil
+}
+
+// APIKeyFind search in a APIKeyListJSON the key by name or ID and
+// returns the APIKeyJSON object
+func (k APIKeyListJSON) APIKeyFind(nameOrID string) (APIKeyJSON, error) {
+	var empty APIKeyJSON
+
+	for _, key := range k {
+		if key.Name == nameOrID || strconv.Itoa(key.ID) == nameOrID {
+			return key, nil
+		}
+	}
+	return empty, errors.New("API key not found")
+}
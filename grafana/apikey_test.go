@@ -0,0 +1,36 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package grafana_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/lstuker/grafana-tool/grafana"
+)
+
+func TestAPIKeyFind(t *testing.T) {
+	var keys grafana.APIKeyListJSON
+	keys_str := []byte(`[{"id":3,"name":"ci","role":"Editor","expiration":null},{"id":7,"name":"grafana-tool","role":"Admin","expiration":"2019-06-01T00:00:00Z"}]`)
+	json.Unmarshal(keys_str, &keys)
+
+	key, _ := keys.APIKeyFind("grafana-tool")
+	if key.ID != 7 || key.Expiration == nil {
+		t.Errorf("Is was  incorrect, got: %d, want: %d.", key.ID, 7)
+	}
+	key, _ = keys.APIKeyFind("3")
+	if key.Name != "ci" || key.Expiration != nil {
+		t.Errorf("Is was  incorrect, got: %s, want: %s.", key.Name, "ci")
+	}
+}
@@ -0,0 +1,369 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grafana
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// volatileDashboardFields change on every save without a change of the
+// dashboard itself
+var volatileDashboardFields = []string{"id", "version", "iteration"}
+
+// Canonicalize returns the dashboard in a stable form for version control.
+// Volatile fields are removed, datasource references are reduced to type
+// and uid, empty references are removed and panels are sorted by their
+// position, the panels of collapsed rows too. The dashboard is changed in
+// place. Keys are sorted when the result is marshaled.
+func Canonicalize(dashboard map[string]interface{}) map[string]interface{} {
+	for _, field := range volatileDashboardFields {
+		delete(dashboard, field)
+	}
+	canonicalizeDatasources(dashboard)
+	sortPanels(dashboard)
+	return dashboard
+}
+
+// Canonical returns the dashboard with meta data in a stable form, see
+// Canonicalize. Volatile meta data like the update time is removed too.
+func (d DashboardRawJSON) Canonical() DashboardRawJSON {
+	d.Dashboard = Canonicalize(d.Dashboard)
+	d.Meta.Version = 0
+	d.Meta.Created = time.Time{}
+	d.Meta.Updated = time.Time{}
+	d.Meta.Expires = time.Time{}
+	return d
+}
+
+// CanonicalJSON marshals v indented by two spaces, with sorted keys, without
+// HTML escaping and with a newline at the end
+func CanonicalJSON(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	err := encoder.Encode(v)
+	return buf.Bytes(), err
+}
+
+// ReencodeJSON marshals v, a document decoded from original and changed, so
+// that the file has a small diff. A canonical original is written with
+// CanonicalJSON. Otherwise the keys keep the order of the original, new keys
+// follow sorted, and the indentation, HTML escaping and final newline of the
+// original are kept. Array items with an id, ex: panels moved out of rows,
+// keep the key order of the original item with the same id.
+func ReencodeJSON(original []byte, v interface{}) ([]byte, error) {
+	var decoded interface{}
+	err := json.Unmarshal(original, &decoded)
+	if err != nil {
+		return nil, err
+	}
+	canonical, err := CanonicalJSON(decoded)
+	if err == nil && bytes.Equal(canonical, original) {
+		return CanonicalJSON(v)
+	}
+
+	order, err := readKeyOrder(json.NewDecoder(bytes.NewReader(original)))
+	if err != nil {
+		return nil, err
+	}
+	escapeHTML := false
+	for _, escaped := range []string{`\u003c`, `\u003e`, `\u0026`} {
+		escapeHTML = escapeHTML || bytes.Contains(original, []byte(escaped))
+	}
+	var compact bytes.Buffer
+	writer := orderedWriter{buf: &compact, ids: map[string]*keyOrder{}, escapeHTML: escapeHTML}
+	order.indexItems(writer.ids)
+	err = writer.write(v, order)
+	if err != nil {
+		return nil, err
+	}
+
+	data := compact.Bytes()
+	if indent := jsonIndent(original); indent != "" {
+		var indented bytes.Buffer
+		err = json.Indent(&indented, data, "", indent)
+		if err != nil {
+			return nil, err
+		}
+		data = indented.Bytes()
+	}
+	if bytes.HasSuffix(original, []byte("\n")) {
+		data = append(data, '\n')
+	}
+	return data, nil
+}
+
+// keyOrder is the order of the keys of a JSON object, with the order of its
+// values and of the items of an array. Value is set for other JSON values,
+// id for objects with an id.
+type keyOrder struct {
+	keys     []string
+	children map[string]*keyOrder
+	items    []*keyOrder
+	value    interface{}
+	id       string
+}
+
+// child returns the order of the value of a key, nil if unknown
+func (o *keyOrder) child(key string) *keyOrder {
+	if o == nil {
+		return nil
+	}
+	return o.children[key]
+}
+
+// item returns the order of an array item, nil if unknown
+func (o *keyOrder) item(i int) *keyOrder {
+	if o == nil || i >= len(o.items) {
+		return nil
+	}
+	return o.items[i]
+}
+
+// indexItems adds the array items with an id below the order to ids, the
+// first item with an id wins
+func (o *keyOrder) indexItems(ids map[string]*keyOrder) {
+	for _, item := range o.items {
+		if _, ok := ids[item.id]; item.id != "" && !ok {
+			ids[item.id] = item
+		}
+		item.indexItems(ids)
+	}
+	for _, child := range o.children {
+		child.indexItems(ids)
+	}
+}
+
+// readKeyOrder reads the next JSON value of the decoder and returns the
+// order of its keys
+func readKeyOrder(decoder *json.Decoder) (*keyOrder, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	order := &keyOrder{children: map[string]*keyOrder{}}
+	switch token {
+	case json.Delim('{'):
+		for decoder.More() {
+			token, err = decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			key, _ := token.(string)
+			child, err := readKeyOrder(decoder)
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := order.children[key]; !ok {
+				order.keys = append(order.keys, key)
+			}
+			order.children[key] = child
+			if key == "id" && child.value != nil {
+				order.id = fmt.Sprint(child.value)
+			}
+		}
+		_, err = decoder.Token()
+	case json.Delim('['):
+		for decoder.More() {
+			child, err := readKeyOrder(decoder)
+			if err != nil {
+				return nil, err
+			}
+			order.items = append(order.items, child)
+		}
+		_, err = decoder.Token()
+	default:
+		order.value = token
+	}
+	return order, err
+}
+
+// orderedWriter writes compact JSON with the key order of an original
+type orderedWriter struct {
+	buf        *bytes.Buffer
+	ids        map[string]*keyOrder
+	escapeHTML bool
+}
+
+// write writes v with the keys of objects in the given order, keys which are
+// not in the order follow sorted
+func (w orderedWriter) write(v interface{}, order *keyOrder) error {
+	switch typed := v.(type) {
+	case map[string]interface{}:
+		keys := []string{}
+		if order != nil {
+			for _, key := range order.keys {
+				if _, ok := typed[key]; ok {
+					keys = append(keys, key)
+				}
+			}
+		}
+		added := []string{}
+		for key := range typed {
+			if order.child(key) == nil {
+				added = append(added, key)
+			}
+		}
+		sort.Strings(added)
+		keys = append(keys, added...)
+
+		w.buf.WriteByte('{')
+		for i, key := range keys {
+			if i > 0 {
+				w.buf.WriteByte(',')
+			}
+			err := writeJSONValue(w.buf, key, w.escapeHTML)
+			if err != nil {
+				return err
+			}
+			w.buf.WriteByte(':')
+			err = w.write(typed[key], order.child(key))
+			if err != nil {
+				return err
+			}
+		}
+		w.buf.WriteByte('}')
+		return nil
+	case []interface{}:
+		w.buf.WriteByte('[')
+		for i, item := range typed {
+			if i > 0 {
+				w.buf.WriteByte(',')
+			}
+			err := w.write(item, w.itemOrder(item, order.item(i)))
+			if err != nil {
+				return err
+			}
+		}
+		w.buf.WriteByte(']')
+		return nil
+	}
+	return writeJSONValue(w.buf, v, w.escapeHTML)
+}
+
+// itemOrder returns the order of the original item with the id of the item,
+// the order at the position of the item if there is none
+func (w orderedWriter) itemOrder(item interface{}, positional *keyOrder) *keyOrder {
+	object, ok := item.(map[string]interface{})
+	if !ok || object["id"] == nil {
+		return positional
+	}
+	if moved, ok := w.ids[fmt.Sprint(object["id"])]; ok {
+		return moved
+	}
+	return positional
+}
+
+// writeJSONValue writes v as compact JSON without a newline
+func writeJSONValue(buf *bytes.Buffer, v interface{}, escapeHTML bool) error {
+	var value bytes.Buffer
+	encoder := json.NewEncoder(&value)
+	encoder.SetEscapeHTML(escapeHTML)
+	err := encoder.Encode(v)
+	if err != nil {
+		return err
+	}
+	buf.Write(bytes.TrimRight(value.Bytes(), "\n"))
+	return nil
+}
+
+// jsonIndent returns the indentation of the second line of the JSON data,
+// empty if the data is on a single line
+func jsonIndent(data []byte) string {
+	newline := bytes.IndexByte(data, '\n')
+	if newline < 0 {
+		return ""
+	}
+	line := data[newline+1:]
+	end := 0
+	for end < len(line) && (line[end] == ' ' || line[end] == '\t') {
+		end++
+	}
+	return string(line[:end])
+}
+
+// canonicalizeDatasources walks the JSON and normalises the value of every
+// datasource key
+func canonicalizeDatasources(value interface{}) {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		for key, child := range typed {
+			if key != "datasource" {
+				canonicalizeDatasources(child)
+				continue
+			}
+			switch ref := child.(type) {
+			case nil:
+				delete(typed, key)
+			case string:
+				if ref == "" {
+					delete(typed, key)
+				}
+			case map[string]interface{}:
+				normalised := map[string]interface{}{}
+				for _, field := range []string{"type", "uid"} {
+					if v, ok := ref[field]; ok && v != "" && v != nil {
+						normalised[field] = v
+					}
+				}
+				if len(normalised) == 0 {
+					delete(typed, key)
+				} else {
+					typed[key] = normalised
+				}
+			}
+		}
+	case []interface{}:
+		for _, child := range typed {
+			canonicalizeDatasources(child)
+		}
+	}
+}
+
+// sortPanels sorts the panels of a dashboard or row top to bottom and
+// left to right
+func sortPanels(parent map[string]interface{}) {
+	panels, ok := parent["panels"].([]interface{})
+	if !ok {
+		return
+	}
+	sort.SliceStable(panels, func(i, j int) bool {
+		yi, xi := gridPosition(panels[i])
+		yj, xj := gridPosition(panels[j])
+		if yi != yj {
+			return yi < yj
+		}
+		return xi < xj
+	})
+	for _, panel := range panels {
+		if nested, ok := panel.(map[string]interface{}); ok {
+			sortPanels(nested)
+		}
+	}
+}
+
+// gridPosition returns y and x of a panel
+func gridPosition(panel interface{}) (float64, float64) {
+	p, _ := panel.(map[string]interface{})
+	gridPos, _ := p["gridPos"].(map[string]interface{})
+	y, _ := gridPos["y"].(float64)
+	x, _ := gridPos["x"].(float64)
+	return y, x
+}
@@ -0,0 +1,99 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package grafana_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/lstuker/grafana-tool/grafana"
+)
+
+func TestCanonicalize(t *testing.T) {
+	var dashboard map[string]interface{}
+	json.Unmarshal([]byte(`{"id":12,"version":7,"iteration":1551398400000,"uid":"abc","title":"Linux",
+		"templating":{"list":[{"name":"host","datasource":{"type":"influxdb","uid":"influx1","name":"influx"}}]},
+		"panels":[
+			{"id":3,"type":"row","collapsed":true,"gridPos":{"x":0,"y":10},"panels":[
+				{"id":5,"gridPos":{"x":12,"y":11}},{"id":4,"gridPos":{"x":0,"y":11},"datasource":null}]},
+			{"id":2,"gridPos":{"x":12,"y":0},"datasource":""},
+			{"id":1,"gridPos":{"x":0,"y":0},"datasource":"influx"}]}`), &dashboard)
+
+	canonical, err := grafana.CanonicalJSON(grafana.Canonicalize(dashboard))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got, expect interface{}
+	json.Unmarshal(canonical, &got)
+	json.Unmarshal([]byte(`{"uid":"abc","title":"Linux",
+		"templating":{"list":[{"name":"host","datasource":{"type":"influxdb","uid":"influx1"}}]},
+		"panels":[
+			{"id":1,"gridPos":{"x":0,"y":0},"datasource":"influx"},
+			{"id":2,"gridPos":{"x":12,"y":0}},
+			{"id":3,"type":"row","collapsed":true,"gridPos":{"x":0,"y":10},"panels":[
+				{"id":4,"gridPos":{"x":0,"y":11}},{"id":5,"gridPos":{"x":12,"y":11}}]}]}`), &expect)
+	if changes := grafana.DiffJSON(expect, got); len(changes) > 0 {
+		t.Errorf("Is was  incorrect, differences: %v", changes)
+	}
+	if canonical[len(canonical)-1] != '\n' {
+		t.Errorf("Expected a newline at the end")
+	}
+}
+
+func TestReencodeJSON(t *testing.T) {
+	original := []byte("{\n\t\"uid\": \"abc\",\n\t\"title\": \"Linux\",\n\t\"panels\": [\n\t\t{\n\t\t\t\"type\": \"graph\",\n\t\t\t\"id\": 1\n\t\t}\n\t]\n}")
+	var document map[string]interface{}
+	json.Unmarshal(original, &document)
+	panel := document["panels"].([]interface{})[0].(map[string]interface{})
+	panel["type"] = "timeseries"
+	panel["fieldConfig"] = map[string]interface{}{}
+	document["schemaVersion"] = 37.0
+
+	got, err := grafana.ReencodeJSON(original, document)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := "{\n\t\"uid\": \"abc\",\n\t\"title\": \"Linux\",\n\t\"panels\": [\n\t\t{\n\t\t\t\"type\": \"timeseries\",\n\t\t\t\"id\": 1,\n\t\t\t\"fieldConfig\": {}\n\t\t}\n\t],\n\t\"schemaVersion\": 37\n}"
+	if string(got) != expect {
+		t.Errorf("Is was  incorrect, got: %s, want: %s.", got, expect)
+	}
+
+	// a canonical file stays canonical
+	canonical, _ := grafana.CanonicalJSON(map[string]interface{}{"uid": "abc", "title": "Linux"})
+	document = map[string]interface{}{"uid": "abc", "title": "Linux", "schemaVersion": 37}
+	got, err = grafana.ReencodeJSON(canonical, document)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect = "{\n  \"schemaVersion\": 37,\n  \"title\": \"Linux\",\n  \"uid\": \"abc\"\n}\n"
+	if string(got) != expect {
+		t.Errorf("Is was  incorrect, got: %s, want: %s.", got, expect)
+	}
+}
+
+func TestReencodeJSONMovedItems(t *testing.T) {
+	original := []byte(`{"schemaVersion":14,"rows":[{"panels":[{"type":"graph","id":2,"title":"CPU"}]}]}`)
+	var dashboard map[string]interface{}
+	json.Unmarshal(original, &dashboard)
+	grafana.MigrateDashboard(dashboard, 16, nil, false)
+
+	got, err := grafana.ReencodeJSON(original, dashboard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := `{"schemaVersion":16,"panels":[{"type":"graph","id":2,"title":"CPU","gridPos":{"h":9,"w":8,"x":0,"y":0}}]}`
+	if string(got) != expect {
+		t.Errorf("Is was  incorrect, got: %s, want: %s.", got, expect)
+	}
+}
@@ -19,202 +19,100 @@ import (
 	"fmt"
 	"net/url"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
 )
 
 // DashboardFullJSON Dashboard export with meta data
 // more info: https://grafana.com/docs/reference/dashboard/
 type DashboardFullJSON struct {
-	Meta struct {
-		Type        string    `json:"type"`
-		CanSave     bool      `json:"canSave"`
-		CanEdit     bool      `json:"canEdit"`
-		CanAdmin    bool      `json:"canAdmin"`
-		CanStar     bool      `json:"canStar"`
-		Slug        string    `json:"slug"`
-		URL         string    `json:"url"`
-		Expires     time.Time `json:"expires"`
-		Created     time.Time `json:"created"`
-		Updated     time.Time `json:"updated"`
-		UpdatedBy   string    `json:"updatedBy"`
-		CreatedBy   string    `json:"createdBy"`
-		Version     int       `json:"version"`
-		HasACL      bool      `json:"hasAcl"`
-		IsFolder    bool      `json:"isFolder"`
-		FolderID    int       `json:"folderId"`
-		FolderTitle string    `json:"folderTitle"`
-		FolderURL   string    `json:"folderUrl"`
-		Provisioned bool      `json:"provisioned"`
-	} `json:"meta"`
-	Dashboard DashboardJSON `json:"dashboard"`
+	Meta      DashboardMetaJSON `json:"meta"`
+	Dashboard DashboardJSON     `json:"dashboard"`
+}
+
+// DashboardRawJSON Dashboard export with meta data, the dashboard is kept
+// as generic JSON so it can be saved again without losing any field
+type DashboardRawJSON struct {
+	Meta      DashboardMetaJSON      `json:"meta"`
+	Dashboard map[string]interface{} `json:"dashboard"`
+}
+
+// DashboardMetaJSON is the meta data Grafana returns with a dashboard
+// more info: https://grafana.com/docs/http_api/dashboard/
+type DashboardMetaJSON struct {
+	Type        string    `json:"type"`
+	CanSave     bool      `json:"canSave"`
+	CanEdit     bool      `json:"canEdit"`
+	CanAdmin    bool      `json:"canAdmin"`
+	CanStar     bool      `json:"canStar"`
+	Slug        string    `json:"slug"`
+	URL         string    `json:"url"`
+	Expires     time.Time `json:"expires"`
+	Created     time.Time `json:"created"`
+	Updated     time.Time `json:"updated"`
+	UpdatedBy   string    `json:"updatedBy"`
+	CreatedBy   string    `json:"createdBy"`
+	Version     int       `json:"version"`
+	HasACL      bool      `json:"hasAcl"`
+	IsFolder    bool      `json:"isFolder"`
+	FolderID    int       `json:"folderId"`
+	FolderUID   string    `json:"folderUid"`
+	FolderTitle string    `json:"folderTitle"`
+	FolderURL   string    `json:"folderUrl"`
+	Provisioned bool      `json:"provisioned"`
+}
+
+// DashboardSaveJSON is the payload to create or update a dashboard
+// more info: https://grafana.com/docs/http_api/dashboard/
+type DashboardSaveJSON struct {
+	Dashboard interface{} `json:"dashboard"`
+	FolderID  int         `json:"folderId"`
+	FolderUID string      `json:"folderUid,omitempty"`
+	Message   string      `json:"message,omitempty"`
+	Overwrite bool        `json:"overwrite"`
+}
+
+// DashboardSaveResultJSON is the answer of Grafana to a saved dashboard
+// more info: https://grafana.com/docs/http_api/dashboard/
+type DashboardSaveResultJSON struct {
+	ID      int    `json:"id"`
+	UID     string `json:"uid"`
+	URL     string `json:"url"`
+	Status  string `json:"status"`
+	Version int    `json:"version"`
+	Slug    string `json:"slug"`
 }
 
 // DashboardJSON more info:
 // https://grafana.com/docs/http_api/dashboard/#dashboard-api
 type DashboardJSON struct {
-	Annotations  Annotations `json:"annotations"`
-	Description  string      `json:"description"`
-	Editable     bool        `json:"editable"`
-	GnetID       interface{} `json:"gnetId"`
-	GraphTooltip int         `json:"graphTooltip"`
-	ID           int         `json:"id"`
-	Iteration    int64       `json:"iteration"`
-	Links        []Links     `json:"links"`
-	Panels       []struct {
-		AliasColors struct {
-		} `json:"aliasColors,omitempty"`
-		Bars       bool        `json:"bars,omitempty"`
-		DashLength int         `json:"dashLength,omitempty"`
-		Dashes     bool        `json:"dashes,omitempty"`
-		Datasource interface{} `json:"datasource,omitempty"`
-		Fill       int         `json:"fill,omitempty"`
-		GridPos    struct {
-			H int `json:"h"`
-			W int `json:"w"`
-			X int `json:"x"`
-			Y int `json:"y"`
-		} `json:"gridPos"`
-		ID     int `json:"id"`
-		Legend struct {
-			Avg     bool `json:"avg"`
-			Current bool `json:"current"`
-			Max     bool `json:"max"`
-			Min     bool `json:"min"`
-			Show    bool `json:"show"`
-			Total   bool `json:"total"`
-			Values  bool `json:"values"`
-		} `json:"legend,omitempty"`
-		Lines           bool          `json:"lines,omitempty"`
-		Linewidth       int           `json:"linewidth,omitempty"`
-		Links           []interface{} `json:"links,omitempty"`
-		NullPointMode   string        `json:"nullPointMode,omitempty"`
-		Percentage      bool          `json:"percentage,omitempty"`
-		Pointradius     float32       `json:"pointradius,omitempty"`
-		Points          bool          `json:"points,omitempty"`
-		Renderer        string        `json:"renderer,omitempty"`
-		SeriesOverrides []interface{} `json:"seriesOverrides,omitempty"`
-		SpaceLength     int           `json:"spaceLength,omitempty"`
-		Stack           bool          `json:"stack,omitempty"`
-		SteppedLine     bool          `json:"steppedLine,omitempty"`
-		Targets         []struct {
-			Alias   string `json:"alias"`
-			GroupBy []struct {
-				Params []string `json:"params"`
-				Type   string   `json:"type"`
-			} `json:"groupBy"`
-			Measurement  string `json:"measurement"`
-			OrderByTime  string `json:"orderByTime"`
-			Policy       string `json:"policy"`
-			Query        string `json:"query"`
-			RawQuery     bool   `json:"rawQuery"`
-			RefID        string `json:"refId"`
-			ResultFormat string `json:"resultFormat"`
-			Select       [][]struct {
-				Params []string `json:"params"`
-				Type   string   `json:"type"`
-			} `json:"select"`
-			Tags []interface{} `json:"tags"`
-		} `json:"targets,omitempty"`
-		Thresholds json.RawMessage `json:"thresholds,omitempty"`
-		TimeFrom   interface{}     `json:"timeFrom,omitempty"`
-		TimeShift  interface{}     `json:"timeShift,omitempty"`
-		Title      string          `json:"title"`
-		Tooltip    struct {
-			Shared    bool   `json:"shared"`
-			Sort      int    `json:"sort"`
-			ValueType string `json:"value_type"`
-		} `json:"tooltip,omitempty"`
-		Type  string `json:"type"`
-		Xaxis struct {
-			Buckets interface{}   `json:"buckets"`
-			Mode    string        `json:"mode"`
-			Name    interface{}   `json:"name"`
-			Show    bool          `json:"show"`
-			Values  []interface{} `json:"values"`
-		} `json:"xaxis,omitempty"`
-		Yaxes []struct {
-			Format  string      `json:"format"`
-			Label   interface{} `json:"label"`
-			LogBase int         `json:"logBase"`
-			Max     interface{} `json:"max"`
-			Min     interface{} `json:"min"`
-			Show    bool        `json:"show"`
-		} `json:"yaxes,omitempty"`
-		Yaxis struct {
-			Align      bool        `json:"align"`
-			AlignLevel interface{} `json:"alignLevel"`
-		} `json:"yaxis,omitempty"`
-		Description string        `json:"description,omitempty"`
-		Collapsed   bool          `json:"collapsed,omitempty"`
-		Panels      []interface{} `json:"panels,omitempty"`
-		Repeat      string        `json:"repeat,omitempty"`
-		ScopedVars  struct {
-			Esxi struct {
-				Selected bool   `json:"selected"`
-				Text     string `json:"text"`
-				Value    string `json:"value"`
-			} `json:"esxi"`
-		} `json:"scopedVars,omitempty"`
-		CacheTimeout    interface{} `json:"cacheTimeout,omitempty"`
-		ColorBackground bool        `json:"colorBackground,omitempty"`
-		ColorValue      bool        `json:"colorValue,omitempty"`
-		Colors          []string    `json:"colors,omitempty"`
-		Decimals        int         `json:"decimals,omitempty"`
-		Format          string      `json:"format,omitempty"`
-		Gauge           struct {
-			MaxValue         int  `json:"maxValue"`
-			MinValue         int  `json:"minValue"`
-			Show             bool `json:"show"`
-			ThresholdLabels  bool `json:"thresholdLabels"`
-			ThresholdMarkers bool `json:"thresholdMarkers"`
-		} `json:"gauge,omitempty"`
-		Interval     interface{} `json:"interval,omitempty"`
-		MappingType  int         `json:"mappingType,omitempty"`
-		MappingTypes []struct {
-			Name  string `json:"name"`
-			Value int    `json:"value"`
-		} `json:"mappingTypes,omitempty"`
-		MaxDataPoints   int         `json:"maxDataPoints,omitempty"`
-		NullText        interface{} `json:"nullText,omitempty"`
-		Postfix         string      `json:"postfix,omitempty"`
-		PostfixFontSize string      `json:"postfixFontSize,omitempty"`
-		Prefix          string      `json:"prefix,omitempty"`
-		PrefixFontSize  string      `json:"prefixFontSize,omitempty"`
-		RangeMaps       []struct {
-			From string `json:"from"`
-			Text string `json:"text"`
-			To   string `json:"to"`
-		} `json:"rangeMaps,omitempty"`
-		Sparkline struct {
-			FillColor string `json:"fillColor"`
-			Full      bool   `json:"full"`
-			LineColor string `json:"lineColor"`
-			Show      bool   `json:"show"`
-		} `json:"sparkline,omitempty"`
-		TableColumn   string `json:"tableColumn,omitempty"`
-		ValueFontSize string `json:"valueFontSize,omitempty"`
-		ValueMaps     []struct {
-			Op    string `json:"op"`
-			Text  string `json:"text"`
-			Value string `json:"value"`
-		} `json:"valueMaps,omitempty"`
-		ValueName string `json:"valueName,omitempty"`
-	} `json:"panels"`
-	SchemaVersion int      `json:"schemaVersion"`
-	Style         string   `json:"style"`
-	Tags          []string `json:"tags"`
+	Annotations   Annotations `json:"annotations"`
+	Description   string      `json:"description"`
+	Editable      bool        `json:"editable"`
+	GnetID        interface{} `json:"gnetId"`
+	GraphTooltip  int         `json:"graphTooltip"`
+	ID            int         `json:"id"`
+	Iteration     int64       `json:"iteration"`
+	Links         []Links     `json:"links"`
+	Panels        []Panel     `json:"panels"`
+	SchemaVersion int         `json:"schemaVersion"`
+	Style         string      `json:"style"`
+	Tags          []string    `json:"tags"`
 	Templating    struct {
 		List []struct {
 			Current struct {
-				Text  string          `json:"text"`
+				Text  interface{}     `json:"text"`
 				Value json.RawMessage `json:"value"`
 			} `json:"current"`
 			Hide           int           `json:"hide"`
 			Label          string        `json:"label"`
 			Name           string        `json:"name"`
 			Options        []interface{} `json:"options"`
-			Query          string        `json:"query"`
+			Query          interface{}   `json:"query"`
 			Refresh        int           `json:"refresh"`
 			Regex          string        `json:"regex,omitempty"`
 			SkipURLSync    bool          `json:"skipUrlSync"`
@@ -223,7 +121,7 @@ type DashboardJSON struct {
 			AutoCount      int           `json:"auto_count,omitempty"`
 			AutoMin        string        `json:"auto_min,omitempty"`
 			AllValue       interface{}   `json:"allValue,omitempty"`
-			Datasource     string        `json:"datasource,omitempty"`
+			Datasource     interface{}   `json:"datasource,omitempty"`
 			IncludeAll     bool          `json:"includeAll,omitempty"`
 			Multi          bool          `json:"multi,omitempty"`
 			Sort           int           `json:"sort,omitempty"`
@@ -241,6 +139,13 @@ type DashboardJSON struct {
 	Version    int        `json:"version"`
 }
 
+// LibraryPanel is the reference of a dashboard panel to a library panel
+// more info: https://grafana.com/docs/reference/dashboard/
+type LibraryPanel struct {
+	UID  string `json:"uid"`
+	Name string `json:"name"`
+}
+
 // Links is part of Grafana dashboard json
 // more info: https://grafana.com/docs/reference/dashboard/
 type Links struct {
@@ -272,13 +177,13 @@ type Timepicker struct {
 // more info: https://grafana.com/docs/reference/dashboard/
 type Annotations struct {
 	List []struct {
-		BuiltIn    int    `json:"builtIn"`
-		Datasource string `json:"datasource"`
-		Enable     bool   `json:"enable"`
-		Hide       bool   `json:"hide"`
-		IconColor  string `json:"iconColor"`
-		Name       string `json:"name"`
-		Type       string `json:"type"`
+		BuiltIn    int         `json:"builtIn"`
+		Datasource interface{} `json:"datasource"`
+		Enable     bool        `json:"enable"`
+		Hide       bool        `json:"hide"`
+		IconColor  string      `json:"iconColor"`
+		Name       string      `json:"name"`
+		Type       string      `json:"type"`
 	} `json:"list"`
 }
 
@@ -325,27 +230,128 @@ func (r *Client) GetDashboardByUID(UID string) (DashboardFullJSON, error) {
 	return records, err
 }
 
+// GetDashboardRawByUID returns the dashboard with the given UID as generic JSON.
+// It reflects GET /api/dashboards/uid/:uid API call.
+// More info: http://docs.grafana.org/http_api/dashboard/
+func (r *Client) GetDashboardRawByUID(UID string) (DashboardRawJSON, error) {
+	var (
+		raw  []byte
+		code int
+		err  error
+	)
+
+	path := fmt.Sprintf("/api/dashboards/uid/%s", UID)
+
+	raw, code, err = r.getRequest(path, nil)
+	records := DashboardRawJSON{}
+
+	if err != nil && code != 200 {
+		return records, err
+	}
+	if code != 200 {
+		return records, fmt.Errorf("HTTP error %d: returns %s", code, raw)
+	}
+
+	err = json.Unmarshal(raw, &records)
+	return records, err
+}
+
+// DashboardExists returns false if Grafana confirms the dashboard with the
+// given UID does not exist.
+// It reflects GET /api/dashboards/uid/:uid API call.
+// More info: http://docs.grafana.org/http_api/dashboard/
+func (r *Client) DashboardExists(UID string) (bool, error) {
+	raw, code, err := r.getRequest(fmt.Sprintf("/api/dashboards/uid/%s", UID), nil)
+
+	if err != nil && code != 200 && code != 404 {
+		return false, err
+	}
+	if code == 404 {
+		return false, nil
+	}
+	if code != 200 {
+		return false, fmt.Errorf("HTTP error %d: returns %s", code, raw)
+	}
+	return true, nil
+}
+
+// SaveDashboard creates or updates a dashboard.
+// It reflects POST /api/dashboards/db API call.
+// More info: http://docs.grafana.org/http_api/dashboard/
+func (r *Client) SaveDashboard(dashboard DashboardSaveJSON) (DashboardSaveResultJSON, error) {
+	var record DashboardSaveResultJSON
+
+	body, err := json.Marshal(dashboard)
+	if err != nil {
+		return record, err
+	}
+
+	raw, code, err := r.postRequest("/api/dashboards/db", nil, body)
+
+	if err != nil && code != 200 {
+		return record, err
+	}
+	if code != 200 {
+		return record, fmt.Errorf("HTTP error %d: returns %s", code, raw)
+	}
+
+	err = json.Unmarshal(raw, &record)
+	return record, err
+}
+
+// SearchQuery filters the results of Search, zero values are not sent
+// to Grafana
+type SearchQuery struct {
+	Query     string
+	Tags      []string
+	FolderIDs []int
+	Type      string
+	Starred   bool
+}
+
 // SearchDashboard returns all folders users has permissions to view.
 // It reflects GET /api/dashboards/uid/:uid API call.
 // More info: http://docs.grafana.org/http_api/dashboard/
 func (r *Client) SearchDashboard(query string, folderIDs string, queryType string) (SearchResult, error) {
+	search := SearchQuery{Query: query, Type: queryType}
+	if folderIDs != "" {
+		for _, folderID := range strings.Split(folderIDs, ",") {
+			id, err := strconv.Atoi(folderID)
+			if err != nil {
+				return SearchResult{}, fmt.Errorf("invalid folder ID %q", folderID)
+			}
+			search.FolderIDs = append(search.FolderIDs, id)
+		}
+	}
+	return r.Search(search)
+}
+
+// Search returns the dashboards and folders matching the query.
+// It reflects GET /api/search API call.
+// More info: http://docs.grafana.org/http_api/folder_dashboard_search/
+func (r *Client) Search(query SearchQuery) (SearchResult, error) {
 	var (
 		raw  []byte
 		code int
 		err  error
 	)
 
-	u := url.URL{}
-	q := u.Query()
+	q := url.Values{}
 
-	if query != "" {
-		q.Set("query", query)
+	if query.Query != "" {
+		q.Set("query", query.Query)
 	}
-	if folderIDs != "" {
-		q.Set("folderIds", folderIDs)
+	for _, tag := range query.Tags {
+		q.Add("tag", tag)
+	}
+	for _, folderID := range query.FolderIDs {
+		q.Add("folderIds", strconv.Itoa(folderID))
 	}
-	if queryType != "" {
-		q.Set("type", queryType)
+	if query.Type != "" {
+		q.Set("type", query.Type)
+	}
+	if query.Starred {
+		q.Set("starred", "true")
 	}
 
 	path := "/api/search"
@@ -365,16 +371,44 @@ func (r *Client) SearchDashboard(query string, folderIDs string, queryType strin
 	return records, err
 }
 
+// transliterations are the letters which are not written as base letter
+// with a combining mark, like the German umlauts
+var transliterations = strings.NewReplacer(
+	"ä", "ae", "ö", "oe", "ü", "ue", "Ä", "Ae", "Ö", "Oe", "Ü", "Ue",
+	"ß", "ss", "æ", "ae", "Æ", "Ae", "ø", "o", "Ø", "O", "å", "a", "Å", "A",
+	"œ", "oe", "Œ", "Oe", "ł", "l", "Ł", "L", "đ", "d", "Đ", "D",
+)
+
+// Slug returns text in a file friendly style. Umlauts are transliterated,
+// accents removed and other letters, like Japanese, are kept.
+// ex: "Übersicht: Café (Zürich)" will return uebersicht_cafe_zuerich
+func Slug(text string) string {
+	text = transliterations.Replace(text)
+	// accents are removed from latin letters only, marks like the Japanese
+	// dakuten change the letter
+	var base rune
+	text = norm.NFC.String(strings.Map(func(r rune) rune {
+		if !unicode.Is(unicode.Mn, r) {
+			base = r
+		} else if unicode.Is(unicode.Latin, base) {
+			return -1
+		}
+		return r
+	}, norm.NFD.String(text)))
+
+	reg1 := regexp.MustCompile(`[^\p{L}\p{N} ]+`)
+	spaces := regexp.MustCompile(`\s+`)
+	text = reg1.ReplaceAllString(text, " ")
+	text = spaces.ReplaceAllString(text, " ")
+	text = strings.TrimSpace(text)
+	return strings.ToLower(strings.Replace(text, " ", "_", -1))
+}
+
 // TitelForFile return the dashboard titel in a file friendly style
 // ex: "Telegraf: Workshop System Dashboard (Windows)" will return
 // telegraf_workshop_system_dashboard_windows
 func (d DashboardJSON) TitelForFile() string {
-	reg1, _ := regexp.Compile("[^a-zA-Z0-9 ]+")
-	spaces := regexp.MustCompile(`\s+`)
-	titel := reg1.ReplaceAllString(d.Title, " ")
-	titel = spaces.ReplaceAllString(titel, " ")
-	titel = strings.TrimSpace(titel)
-	return strings.ToLower(strings.Replace(titel, " ", "_", -1))
+	return Slug(d.Title)
 }
 
 // TitelFirstWord reurns from titel the first word
@@ -383,3 +417,38 @@ func (d DashboardJSON) TitelFirstWord() string {
 	name := d.TitelForFile()
 	return strings.Split(name, "_")[0]
 }
+
+// LibraryPanelUIDs returns the UIDs of all library panels the dashboard
+// uses, including panels in collapsed rows
+func (d DashboardJSON) LibraryPanelUIDs() []string {
+	uids := []string{}
+	seen := map[string]bool{}
+	add := func(uid string) {
+		if uid != "" && !seen[uid] {
+			seen[uid] = true
+			uids = append(uids, uid)
+		}
+	}
+
+	for _, panel := range d.AllPanels() {
+		if panel.LibraryPanel != nil {
+			add(panel.LibraryPanel.UID)
+		}
+	}
+	return uids
+}
+
+// DeleteDashboardByUID deletes the dashboard with the given UID.
+// It reflects DELETE /api/dashboards/uid/:uid API call.
+// More info: http://docs.grafana.org/http_api/dashboard/
+func (r *Client) DeleteDashboardByUID(UID string) error {
+	raw, code, err := r.deleteRequest(fmt.Sprintf("/api/dashboards/uid/%s", UID))
+
+	if err != nil && code != 200 {
+		return err
+	}
+	if code != 200 {
+		return fmt.Errorf("HTTP error %d: returns %s", code, raw)
+	}
+	return nil
+}
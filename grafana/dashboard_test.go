@@ -29,6 +29,10 @@ func TestTitelForFile(t *testing.T) {
 		{`{"title":"Linux$ Memory"}`, "linux_memory"},
 		{`{"title":"Linux/Memory"}`, "linux_memory"},
 		{`{"title":"Linux (Memory) system"}`, "linux_memory_system"},
+		{`{"title":"Übersicht Größe"}`, "uebersicht_groesse"},
+		{`{"title":"Café Señor"}`, "cafe_senor"},
+		{`{"title":"サーバー 監視"}`, "サーバー_監視"},
+		{`{"title":"!!!"}`, ""},
 	}
 
 	for _, table := range tables {
@@ -63,3 +67,19 @@ func TestTitelFirstWord(t *testing.T) {
 		}
 	}
 }
+
+func TestLibraryPanelUIDs(t *testing.T) {
+	var dashboard grafana.DashboardJSON
+	dashboard_json := []byte(`{"panels":[
+		{"id":1,"type":"graph"},
+		{"id":2,"libraryPanel":{"uid":"cpu","name":"CPU"}},
+		{"id":3,"type":"row","collapsed":true,"panels":[
+			{"id":4,"libraryPanel":{"uid":"memory","name":"Memory"}},
+			{"id":5,"libraryPanel":{"uid":"cpu","name":"CPU"}}]}]}`)
+	json.Unmarshal(dashboard_json, &dashboard)
+
+	uids := dashboard.LibraryPanelUIDs()
+	if len(uids) != 2 || uids[0] != "cpu" || uids[1] != "memory" {
+		t.Errorf("Is was  incorrect, got: %v, want: %v.", uids, []string{"cpu", "memory"})
+	}
+}
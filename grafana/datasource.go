@@ -0,0 +1,115 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grafana
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// DatasourceListJSON is a list of datasources from the Grafana API
+// More info: https://grafana.com/docs/http_api/data_source/
+type DatasourceListJSON []DatasourceJSON
+
+// DatasourceJSON is a datasource from the Grafana API. Secrets are never
+// returned by Grafana, SecureJSONFields only tells which of them are set.
+// More info: https://grafana.com/docs/http_api/data_source/
+type DatasourceJSON struct {
+	ID               int                    `json:"id,omitempty"`
+	UID              string                 `json:"uid"`
+	OrgID            int                    `json:"orgId,omitempty"`
+	Name             string                 `json:"name"`
+	Type             string                 `json:"type"`
+	Access           string                 `json:"access"`
+	URL              string                 `json:"url"`
+	User             string                 `json:"user"`
+	Database         string                 `json:"database"`
+	BasicAuth        bool                   `json:"basicAuth"`
+	BasicAuthUser    string                 `json:"basicAuthUser"`
+	WithCredentials  bool                   `json:"withCredentials"`
+	IsDefault        bool                   `json:"isDefault"`
+	JSONData         map[string]interface{} `json:"jsonData,omitempty"`
+	SecureJSONFields map[string]bool        `json:"secureJsonFields,omitempty"`
+	ReadOnly         bool                   `json:"readOnly,omitempty"`
+}
+
+// GetDatasources returns all datasources of the organisation.
+// It reflects GET /api/datasources API call.
+// More info: https://grafana.com/docs/http_api/data_source/
+func (r *Client) GetDatasources() (DatasourceListJSON, error) {
+	var (
+		records DatasourceListJSON
+		raw     []byte
+		code    int
+		err     error
+	)
+
+	raw, code, err = r.getRequest("/api/datasources", nil)
+
+	if err != nil && code != 200 {
+		return records, err
+	}
+	if code != 200 {
+		return nil, fmt.Errorf("HTTP error %d: returns %s", code, raw)
+	}
+
+	err = json.Unmarshal(raw, &records)
+	return records, err
+}
+
+// CreateDatasource creates a datasource and returns its ID. Secrets are not
+// copied, they have to be set again on the new datasource.
+// It reflects POST /api/datasources API call.
+// More info: https://grafana.com/docs/http_api/data_source/
+func (r *Client) CreateDatasource(datasource DatasourceJSON) (int, error) {
+	datasource.ID = 0
+	datasource.OrgID = 0
+	datasource.SecureJSONFields = nil
+	datasource.ReadOnly = false
+
+	body, err := json.Marshal(datasource)
+	if err != nil {
+		return 0, err
+	}
+
+	raw, code, err := r.postRequest("/api/datasources", nil, body)
+
+	if err != nil && code != 200 {
+		return 0, err
+	}
+	if code != 200 {
+		return 0, fmt.Errorf("HTTP error %d: returns %s", code, raw)
+	}
+
+	result := struct {
+		ID int `json:"id"`
+	}{}
+	err = json.Unmarshal(raw, &result)
+	return result.ID, err
+}
+
+// DatasourceFindByName search in a DatasourceListJSON the datasource by name
+// and returns the DatasourceJSON object
+func (d DatasourceListJSON) DatasourceFindByName(name string) (DatasourceJSON, error) {
+	var empty DatasourceJSON
+
+	for _, datasource := range d {
+		if datasource.Name == name {
+			return datasource, nil
+		}
+	}
+	return empty, errors.New("Datasource not found")
+}
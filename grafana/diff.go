@@ -0,0 +1,279 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grafana
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Kinds of a PanelChange, JSONAdded, JSONRemoved and JSONChanged are used too
+const (
+	PanelMoved = "moved"
+)
+
+// DashboardDiff are the differences between two dashboards by panel,
+// template variable and other dashboard settings
+type DashboardDiff struct {
+	Panels    []PanelChange    `json:"panels"`
+	Variables []VariableChange `json:"variables"`
+	Settings  []JSONChange     `json:"settings"`
+}
+
+// PanelChange is an added, removed, moved or changed panel. Panels are
+// matched by ID, the panels of collapsed rows are included. Queries are the
+// changes of the targets, Changes all other changes of the panel.
+type PanelChange struct {
+	ID         int                    `json:"id"`
+	Title      string                 `json:"title"`
+	Kind       string                 `json:"kind"`
+	Moved      bool                   `json:"moved,omitempty"`
+	OldGridPos map[string]interface{} `json:"oldGridPos,omitempty"`
+	NewGridPos map[string]interface{} `json:"newGridPos,omitempty"`
+	Queries    []JSONChange           `json:"queries,omitempty"`
+	Changes    []JSONChange           `json:"changes,omitempty"`
+}
+
+// VariableChange is an added, removed or changed template variable,
+// variables are matched by name
+type VariableChange struct {
+	Name    string       `json:"name"`
+	Kind    string       `json:"kind"`
+	Changes []JSONChange `json:"changes,omitempty"`
+}
+
+// DiffDashboards compares two dashboards panel by panel. Both are brought
+// into the canonical form first, so volatile fields and the order of the
+// panels are no differences.
+func DiffDashboards(a, b map[string]interface{}) DashboardDiff {
+	a = Canonicalize(copyJSON(a))
+	b = Canonicalize(copyJSON(b))
+	diff := DashboardDiff{Panels: []PanelChange{}, Variables: []VariableChange{}}
+
+	panelsA := panelsByID(a)
+	panelsB := panelsByID(b)
+	for _, id := range unionKeys(panelsA, panelsB) {
+		panelA, inA := panelsA[id]
+		panelB, inB := panelsB[id]
+		switch {
+		case !inA:
+			diff.Panels = append(diff.Panels, newPanelChange(panelB, JSONAdded))
+		case !inB:
+			diff.Panels = append(diff.Panels, newPanelChange(panelA, JSONRemoved))
+		default:
+			if change, ok := diffPanel(panelA, panelB); ok {
+				diff.Panels = append(diff.Panels, change)
+			}
+		}
+	}
+
+	variablesA := variablesByName(a)
+	variablesB := variablesByName(b)
+	for _, name := range unionKeys(variablesA, variablesB) {
+		variableA, inA := variablesA[name]
+		variableB, inB := variablesB[name]
+		switch {
+		case !inA:
+			diff.Variables = append(diff.Variables, VariableChange{Name: name, Kind: JSONAdded})
+		case !inB:
+			diff.Variables = append(diff.Variables, VariableChange{Name: name, Kind: JSONRemoved})
+		default:
+			if changes := DiffJSON(variableA, variableB); len(changes) > 0 {
+				diff.Variables = append(diff.Variables, VariableChange{Name: name, Kind: JSONChanged, Changes: changes})
+			}
+		}
+	}
+
+	delete(a, "panels")
+	delete(b, "panels")
+	if templating, ok := a["templating"].(map[string]interface{}); ok {
+		delete(templating, "list")
+	}
+	if templating, ok := b["templating"].(map[string]interface{}); ok {
+		delete(templating, "list")
+	}
+	diff.Settings = DiffJSON(a, b)
+	return diff
+}
+
+// Empty returns true if the dashboards are the same
+func (d DashboardDiff) Empty() bool {
+	return len(d.Panels) == 0 && len(d.Variables) == 0 && len(d.Settings) == 0
+}
+
+// String returns the differences in a readable form, one line per panel,
+// variable or setting followed by the indented changes
+func (d DashboardDiff) String() string {
+	var b strings.Builder
+	for _, panel := range d.Panels {
+		switch panel.Kind {
+		case JSONAdded:
+			fmt.Fprintf(&b, "+ panel %d %q added\n", panel.ID, panel.Title)
+		case JSONRemoved:
+			fmt.Fprintf(&b, "- panel %d %q removed\n", panel.ID, panel.Title)
+		default:
+			fmt.Fprintf(&b, "~ panel %d %q %s\n", panel.ID, panel.Title, panel.Kind)
+		}
+		if panel.Moved {
+			fmt.Fprintf(&b, "    moved from %s to %s\n", formatGridPos(panel.OldGridPos), formatGridPos(panel.NewGridPos))
+		}
+		for _, change := range panel.Queries {
+			fmt.Fprintf(&b, "    query %s\n", change)
+		}
+		for _, change := range panel.Changes {
+			fmt.Fprintf(&b, "    %s\n", change)
+		}
+	}
+	for _, variable := range d.Variables {
+		switch variable.Kind {
+		case JSONAdded:
+			fmt.Fprintf(&b, "+ variable %s added\n", variable.Name)
+		case JSONRemoved:
+			fmt.Fprintf(&b, "- variable %s removed\n", variable.Name)
+		default:
+			fmt.Fprintf(&b, "~ variable %s changed\n", variable.Name)
+		}
+		for _, change := range variable.Changes {
+			fmt.Fprintf(&b, "    %s\n", change)
+		}
+	}
+	for _, change := range d.Settings {
+		fmt.Fprintf(&b, "%s\n", change)
+	}
+	return b.String()
+}
+
+func newPanelChange(panel map[string]interface{}, kind string) PanelChange {
+	id, _ := panel["id"].(float64)
+	title, _ := panel["title"].(string)
+	return PanelChange{ID: int(id), Title: title, Kind: kind}
+}
+
+// diffPanel compares two versions of a panel, ok is false without changes
+func diffPanel(a, b map[string]interface{}) (PanelChange, bool) {
+	change := newPanelChange(b, JSONChanged)
+	gridA, _ := a["gridPos"].(map[string]interface{})
+	gridB, _ := b["gridPos"].(map[string]interface{})
+	if !reflect.DeepEqual(gridA, gridB) {
+		change.Moved = true
+		change.OldGridPos = gridA
+		change.NewGridPos = gridB
+	}
+
+	for _, jsonChange := range DiffJSON(a, b) {
+		switch {
+		case strings.HasPrefix(jsonChange.Path, "gridPos"):
+		case strings.HasPrefix(jsonChange.Path, "targets"):
+			change.Queries = append(change.Queries, jsonChange)
+		default:
+			change.Changes = append(change.Changes, jsonChange)
+		}
+	}
+
+	if len(change.Queries) == 0 && len(change.Changes) == 0 {
+		if !change.Moved {
+			return change, false
+		}
+		change.Kind = PanelMoved
+	}
+	return change, true
+}
+
+// panelsByID returns all panels including the panels of collapsed rows by
+// ID, the panels of rows are removed from the row itself. Panels without ID
+// are identified by title.
+func panelsByID(dashboard map[string]interface{}) map[string]map[string]interface{} {
+	panels := map[string]map[string]interface{}{}
+	var add func(list interface{})
+	add = func(list interface{}) {
+		items, _ := list.([]interface{})
+		for _, item := range items {
+			panel, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if nested, ok := panel["panels"]; ok {
+				delete(panel, "panels")
+				add(nested)
+			}
+			key := fmt.Sprintf("title:%v", panel["title"])
+			if id, ok := panel["id"].(float64); ok {
+				key = fmt.Sprintf("%010d", int(id))
+			}
+			panels[key] = panel
+		}
+	}
+	add(dashboard["panels"])
+	return panels
+}
+
+// variablesByName returns the template variables by name
+func variablesByName(dashboard map[string]interface{}) map[string]map[string]interface{} {
+	variables := map[string]map[string]interface{}{}
+	templating, _ := dashboard["templating"].(map[string]interface{})
+	list, _ := templating["list"].([]interface{})
+	for _, item := range list {
+		if variable, ok := item.(map[string]interface{}); ok {
+			name, _ := variable["name"].(string)
+			variables[name] = variable
+		}
+	}
+	return variables
+}
+
+// unionKeys returns the sorted keys of both maps
+func unionKeys(a, b map[string]map[string]interface{}) []string {
+	keys := []string{}
+	for key := range a {
+		keys = append(keys, key)
+	}
+	for key := range b {
+		if _, ok := a[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func formatGridPos(gridPos map[string]interface{}) string {
+	return fmt.Sprintf("x=%v y=%v w=%v h=%v", gridPos["x"], gridPos["y"], gridPos["w"], gridPos["h"])
+}
+
+// copyJSON returns a deep copy of a decoded JSON object
+func copyJSON(value map[string]interface{}) map[string]interface{} {
+	copied, _ := copyJSONValue(value).(map[string]interface{})
+	return copied
+}
+
+func copyJSONValue(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(typed))
+		for key, child := range typed {
+			copied[key] = copyJSONValue(child)
+		}
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, len(typed))
+		for i, child := range typed {
+			copied[i] = copyJSONValue(child)
+		}
+		return copied
+	}
+	return value
+}
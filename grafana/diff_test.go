@@ -0,0 +1,77 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package grafana_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/lstuker/grafana-tool/grafana"
+)
+
+func TestDiffDashboards(t *testing.T) {
+	var a, b map[string]interface{}
+	json.Unmarshal([]byte(`{"version":3,"title":"Linux","refresh":"5m",
+		"templating":{"list":[{"name":"host","query":"SHOW TAG VALUES"},{"name":"old"}]},
+		"panels":[
+			{"id":1,"title":"CPU","gridPos":{"x":0,"y":0,"w":12,"h":8},"targets":[{"refId":"A","expr":"up"}]},
+			{"id":2,"title":"Memory","gridPos":{"x":12,"y":0,"w":12,"h":8}},
+			{"id":3,"title":"Disk","gridPos":{"x":0,"y":8,"w":12,"h":8}},
+			{"id":4,"type":"row","collapsed":true,"gridPos":{"x":0,"y":16},"panels":[
+				{"id":5,"title":"Network","gridPos":{"x":0,"y":17,"w":12,"h":8}}]}]}`), &a)
+	json.Unmarshal([]byte(`{"version":4,"title":"Linux","refresh":"1m",
+		"templating":{"list":[{"name":"host","query":"SHOW TAG VALUES WITH KEY = host"},{"name":"new"}]},
+		"panels":[
+			{"id":2,"title":"Memory","gridPos":{"x":12,"y":0,"w":12,"h":8}},
+			{"id":1,"title":"CPU","gridPos":{"x":0,"y":0,"w":12,"h":8},"targets":[{"refId":"A","expr":"up{job=\"node\"}"}]},
+			{"id":3,"title":"Disk","gridPos":{"x":12,"y":8,"w":12,"h":8}},
+			{"id":4,"type":"row","collapsed":true,"gridPos":{"x":0,"y":16},"panels":[
+				{"id":6,"title":"Load","gridPos":{"x":0,"y":17,"w":12,"h":8}}]}]}`), &b)
+
+	diff := grafana.DiffDashboards(a, b)
+	kinds := map[int]string{}
+	for _, panel := range diff.Panels {
+		kinds[panel.ID] = panel.Kind
+	}
+	expect := map[int]string{1: "changed", 3: "moved", 5: "removed", 6: "added"}
+	if len(kinds) != len(expect) {
+		t.Errorf("Is was  incorrect, got: %v, want: %v.", kinds, expect)
+	}
+	for id, kind := range expect {
+		if kinds[id] != kind {
+			t.Errorf("Is was  incorrect for panel %d, got: %s, want: %s.", id, kinds[id], kind)
+		}
+	}
+	if len(diff.Panels) > 0 && (diff.Panels[0].ID != 1 || len(diff.Panels[0].Queries) != 1) {
+		t.Errorf("Expected a changed query of panel 1, got: %v", diff.Panels[0])
+	}
+	if len(diff.Variables) != 3 {
+		t.Errorf("Expected 3 changed variables, got: %v", diff.Variables)
+	}
+	if len(diff.Settings) != 1 || diff.Settings[0].Path != "refresh" {
+		t.Errorf("Expected a changed refresh, got: %v", diff.Settings)
+	}
+	if a["version"] != float64(3) {
+		t.Errorf("Expected the dashboards to be unchanged")
+	}
+}
+
+func TestDiffDashboardsEmpty(t *testing.T) {
+	var a, b map[string]interface{}
+	json.Unmarshal([]byte(`{"id":1,"version":3,"panels":[{"id":1,"gridPos":{"x":0,"y":0}},{"id":2,"gridPos":{"x":0,"y":8}}]}`), &a)
+	json.Unmarshal([]byte(`{"id":7,"version":9,"panels":[{"id":2,"gridPos":{"x":0,"y":8}},{"id":1,"gridPos":{"x":0,"y":0}}]}`), &b)
+	if diff := grafana.DiffDashboards(a, b); !diff.Empty() {
+		t.Errorf("Expected no differences, got: %s", diff)
+	}
+}
@@ -78,3 +78,40 @@ func (f FolderListJSON) FolderFindByName(title string) (FolderJSON, error) {
 	}
 	return empty, errors.New("Folder not found")
 }
+
+// CreateFolder creates a folder, an empty UID lets Grafana generate one.
+// It reflects POST /api/folders API call.
+// More info: http://docs.grafana.org/http_api/folder/
+func (r *Client) CreateFolder(UID string, title string) (FolderJSON, error) {
+	var record FolderJSON
+
+	body, err := json.Marshal(FolderJSON{UID: UID, Title: title})
+	if err != nil {
+		return record, err
+	}
+
+	raw, code, err := r.postRequest("/api/folders", nil, body)
+
+	if err != nil && code != 200 {
+		return record, err
+	}
+	if code != 200 {
+		return record, fmt.Errorf("HTTP error %d: returns %s", code, raw)
+	}
+
+	err = json.Unmarshal(raw, &record)
+	return record, err
+}
+
+// FolderFindByID search in a FolderListJSON the folder by ID and
+// returns the FolderJSON object
+func (f FolderListJSON) FolderFindByID(ID int) (FolderJSON, error) {
+	var empty FolderJSON
+
+	for _, folder := range f {
+		if folder.ID == ID {
+			return folder, nil
+		}
+	}
+	return empty, errors.New("Folder not found")
+}
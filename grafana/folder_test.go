@@ -29,3 +29,13 @@ func TestFindFolderByName(t *testing.T) {
 		t.Errorf("Is was  incorrect, got: %d, want: %d.", folder.ID, 83)
 	}
 }
+
+func TestFindFolderByID(t *testing.T) {
+	var folders grafana.FolderListJSON
+	folders_str := []byte(`[{"id":98,"uid":"HeZIp-Qmk","title":"bank-now"},{"id":83,"uid":"5_oQ-G8mk","title":"Fun"}]`)
+	json.Unmarshal(folders_str, &folders)
+	folder, _ := folders.FolderFindByID(83)
+	if folder.UID != "5_oQ-G8mk" {
+		t.Errorf("Is was  incorrect, got: %s, want: %s.", folder.UID, "5_oQ-G8mk")
+	}
+}
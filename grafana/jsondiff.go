@@ -0,0 +1,122 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grafana
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// Kinds of a JSONChange
+const (
+	JSONAdded   = "added"
+	JSONRemoved = "removed"
+	JSONChanged = "changed"
+)
+
+// JSONChange is a difference between two JSON documents at Path,
+// ex: panels[2].targets[0].expr
+type JSONChange struct {
+	Path string      `json:"path"`
+	Kind string      `json:"kind"`
+	Old  interface{} `json:"old,omitempty"`
+	New  interface{} `json:"new,omitempty"`
+}
+
+// DiffJSON compares two decoded JSON documents and returns the differences
+// of their leaves. Arrays are compared element by element.
+func DiffJSON(a, b interface{}) []JSONChange {
+	changes := []JSONChange{}
+	diffJSON("", a, b, &changes)
+	return changes
+}
+
+func diffJSON(path string, a, b interface{}, changes *[]JSONChange) {
+	mapA, okA := a.(map[string]interface{})
+	mapB, okB := b.(map[string]interface{})
+	if okA && okB {
+		keys := []string{}
+		for key := range mapA {
+			keys = append(keys, key)
+		}
+		for key := range mapB {
+			if _, ok := mapA[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			childPath := key
+			if path != "" {
+				childPath = path + "." + key
+			}
+			valueA, inA := mapA[key]
+			valueB, inB := mapB[key]
+			switch {
+			case !inA:
+				*changes = append(*changes, JSONChange{Path: childPath, Kind: JSONAdded, New: valueB})
+			case !inB:
+				*changes = append(*changes, JSONChange{Path: childPath, Kind: JSONRemoved, Old: valueA})
+			default:
+				diffJSON(childPath, valueA, valueB, changes)
+			}
+		}
+		return
+	}
+
+	listA, okA := a.([]interface{})
+	listB, okB := b.([]interface{})
+	if okA && okB {
+		for i := 0; i < len(listA) || i < len(listB); i++ {
+			childPath := fmt.Sprintf("%s[%d]", path, i)
+			switch {
+			case i >= len(listA):
+				*changes = append(*changes, JSONChange{Path: childPath, Kind: JSONAdded, New: listB[i]})
+			case i >= len(listB):
+				*changes = append(*changes, JSONChange{Path: childPath, Kind: JSONRemoved, Old: listA[i]})
+			default:
+				diffJSON(childPath, listA[i], listB[i], changes)
+			}
+		}
+		return
+	}
+
+	if !reflect.DeepEqual(a, b) {
+		*changes = append(*changes, JSONChange{Path: path, Kind: JSONChanged, Old: a, New: b})
+	}
+}
+
+// String returns the change in a readable form, ex:
+// ~ panels[0].title: "CPU" => "CPU usage"
+func (c JSONChange) String() string {
+	switch c.Kind {
+	case JSONAdded:
+		return fmt.Sprintf("+ %s: %s", c.Path, compactJSON(c.New))
+	case JSONRemoved:
+		return fmt.Sprintf("- %s: %s", c.Path, compactJSON(c.Old))
+	}
+	return fmt.Sprintf("~ %s: %s => %s", c.Path, compactJSON(c.Old), compactJSON(c.New))
+}
+
+func compactJSON(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
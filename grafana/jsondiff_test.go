@@ -0,0 +1,44 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package grafana_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/lstuker/grafana-tool/grafana"
+)
+
+func TestDiffJSON(t *testing.T) {
+	var a, b interface{}
+	json.Unmarshal([]byte(`{"title":"CPU","tags":["linux"],"panels":[{"id":1,"title":"Load"}],"refresh":"1m"}`), &a)
+	json.Unmarshal([]byte(`{"title":"CPU usage","tags":["linux","os"],"panels":[{"id":1,"title":"Load"}],"editable":true}`), &b)
+
+	expect := []string{
+		`+ editable: true`,
+		`- refresh: "1m"`,
+		`+ tags[1]: "os"`,
+		`~ title: "CPU" => "CPU usage"`,
+	}
+
+	changes := grafana.DiffJSON(a, b)
+	if len(changes) != len(expect) {
+		t.Fatalf("Is was  incorrect, got: %v, want: %v.", changes, expect)
+	}
+	for i, change := range changes {
+		if change.String() != expect[i] {
+			t.Errorf("Is was  incorrect, got: %s, want: %s.", change.String(), expect[i])
+		}
+	}
+}
@@ -0,0 +1,221 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grafana
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// LibraryPanelListJSON is a list of library panels from the Grafana API
+// More info: https://grafana.com/docs/http_api/library_element/
+type LibraryPanelListJSON []LibraryPanelJSON
+
+// LibraryPanelJSON is a library panel from the Grafana API, Model is the
+// panel JSON which is shared by all dashboards using the library panel
+// More info: https://grafana.com/docs/http_api/library_element/
+type LibraryPanelJSON struct {
+	ID          int             `json:"id,omitempty"`
+	UID         string          `json:"uid"`
+	FolderID    int             `json:"folderId"`
+	FolderUID   string          `json:"folderUid"`
+	Name        string          `json:"name"`
+	Kind        int             `json:"kind"`
+	Type        string          `json:"type"`
+	Description string          `json:"description"`
+	Model       json.RawMessage `json:"model"`
+	Version     int             `json:"version,omitempty"`
+	Meta        struct {
+		FolderName          string    `json:"folderName"`
+		ConnectedDashboards int       `json:"connectedDashboards"`
+		Updated             time.Time `json:"updated"`
+	} `json:"meta"`
+}
+
+// LibraryPanelConnectionJSON is a dashboard using a library panel, older
+// Grafana versions only return the dashboard ID as ConnectionID
+// More info: https://grafana.com/docs/http_api/library_element/
+type LibraryPanelConnectionJSON struct {
+	ID            int    `json:"id"`
+	ElementID     int    `json:"elementId"`
+	ConnectionID  int    `json:"connectionId"`
+	ConnectionUID string `json:"connectionUid"`
+}
+
+// libraryPanelKind is the kind of library elements which are panels
+const libraryPanelKind = 1
+
+// GetLibraryPanels returns all library panels.
+// It reflects GET /api/library-elements API call.
+// More info: https://grafana.com/docs/http_api/library_element/
+func (r *Client) GetLibraryPanels() (LibraryPanelListJSON, error) {
+	var (
+		records LibraryPanelListJSON
+		raw     []byte
+		code    int
+		err     error
+	)
+
+	perPage := 100
+	for page := 1; ; page++ {
+		q := url.Values{}
+		q.Set("kind", strconv.Itoa(libraryPanelKind))
+		q.Set("perPage", strconv.Itoa(perPage))
+		q.Set("page", strconv.Itoa(page))
+
+		raw, code, err = r.getRequest("/api/library-elements", q)
+
+		if err != nil && code != 200 {
+			return records, err
+		}
+		if code != 200 {
+			return nil, fmt.Errorf("HTTP error %d: returns %s", code, raw)
+		}
+
+		result := struct {
+			Result struct {
+				TotalCount int                  `json:"totalCount"`
+				Elements   LibraryPanelListJSON `json:"elements"`
+			} `json:"result"`
+		}{}
+		err = json.Unmarshal(raw, &result)
+		if err != nil {
+			return records, err
+		}
+		records = append(records, result.Result.Elements...)
+		if len(result.Result.Elements) < perPage || len(records) >= result.Result.TotalCount {
+			return records, nil
+		}
+	}
+}
+
+// ErrLibraryPanelNotFound is returned when Grafana has no library panel with the UID
+var ErrLibraryPanelNotFound = errors.New("library panel not found")
+
+// GetLibraryPanelByUID returns the library panel with the given UID,
+// ErrLibraryPanelNotFound if it does not exist.
+// It reflects GET /api/library-elements/:uid API call.
+// More info: https://grafana.com/docs/http_api/library_element/
+func (r *Client) GetLibraryPanelByUID(UID string) (LibraryPanelJSON, error) {
+	result := struct {
+		Result LibraryPanelJSON `json:"result"`
+	}{}
+
+	raw, code, err := r.getRequest(fmt.Sprintf("/api/library-elements/%s", UID), nil)
+
+	if err != nil && code != 200 {
+		return result.Result, err
+	}
+	if code == 404 {
+		return result.Result, ErrLibraryPanelNotFound
+	}
+	if code != 200 {
+		return result.Result, fmt.Errorf("HTTP error %d: returns %s", code, raw)
+	}
+
+	err = json.Unmarshal(raw, &result)
+	return result.Result, err
+}
+
+// CreateLibraryPanel creates a library panel keeping its UID.
+// It reflects POST /api/library-elements API call.
+// More info: https://grafana.com/docs/http_api/library_element/
+func (r *Client) CreateLibraryPanel(panel LibraryPanelJSON) (LibraryPanelJSON, error) {
+	result := struct {
+		Result LibraryPanelJSON `json:"result"`
+	}{}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"uid":       panel.UID,
+		"folderUid": panel.FolderUID,
+		"name":      panel.Name,
+		"model":     panel.Model,
+		"kind":      libraryPanelKind,
+	})
+	if err != nil {
+		return result.Result, err
+	}
+
+	raw, code, err := r.postRequest("/api/library-elements", nil, body)
+
+	if err != nil && code != 200 {
+		return result.Result, err
+	}
+	if code != 200 {
+		return result.Result, fmt.Errorf("HTTP error %d: returns %s", code, raw)
+	}
+
+	err = json.Unmarshal(raw, &result)
+	return result.Result, err
+}
+
+// UpdateLibraryPanel replaces name, folder and model of an existing library
+// panel, version must be the current version on Grafana.
+// It reflects PATCH /api/library-elements/:uid API call.
+// More info: https://grafana.com/docs/http_api/library_element/
+func (r *Client) UpdateLibraryPanel(panel LibraryPanelJSON, version int) (LibraryPanelJSON, error) {
+	result := struct {
+		Result LibraryPanelJSON `json:"result"`
+	}{}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"uid":       panel.UID,
+		"folderUid": panel.FolderUID,
+		"name":      panel.Name,
+		"model":     panel.Model,
+		"kind":      libraryPanelKind,
+		"version":   version,
+	})
+	if err != nil {
+		return result.Result, err
+	}
+
+	raw, code, err := r.patchRequest(fmt.Sprintf("/api/library-elements/%s", panel.UID), nil, body)
+
+	if err != nil && code != 200 {
+		return result.Result, err
+	}
+	if code != 200 {
+		return result.Result, fmt.Errorf("HTTP error %d: returns %s", code, raw)
+	}
+
+	err = json.Unmarshal(raw, &result)
+	return result.Result, err
+}
+
+// GetLibraryPanelConnections returns the dashboards using a library panel.
+// It reflects GET /api/library-elements/:uid/connections API call.
+// More info: https://grafana.com/docs/http_api/library_element/
+func (r *Client) GetLibraryPanelConnections(UID string) ([]LibraryPanelConnectionJSON, error) {
+	result := struct {
+		Result []LibraryPanelConnectionJSON `json:"result"`
+	}{}
+
+	raw, code, err := r.getRequest(fmt.Sprintf("/api/library-elements/%s/connections", UID), nil)
+
+	if err != nil && code != 200 {
+		return result.Result, err
+	}
+	if code != 200 {
+		return nil, fmt.Errorf("HTTP error %d: returns %s", code, raw)
+	}
+
+	err = json.Unmarshal(raw, &result)
+	return result.Result, err
+}
@@ -0,0 +1,251 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grafana
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Severities of lint rules
+const (
+	LintError   = "error"
+	LintWarning = "warning"
+	LintInfo    = "info"
+)
+
+// LintIgnoreTag is the dashboard tag to skip all rules, the tag
+// "lint-ignore:<rule>" skips a single rule
+const LintIgnoreTag = "lint-ignore"
+
+// LintRule is a convention dashboards should follow
+type LintRule struct {
+	ID          string `json:"id"`
+	Description string `json:"description"`
+	Severity    string `json:"severity"`
+	check       func(d DashboardJSON) []LintProblem
+}
+
+// LintProblem is a violation of a lint rule, PanelID is 0 for problems of
+// the dashboard itself
+type LintProblem struct {
+	Rule     string `json:"rule"`
+	Severity string `json:"severity"`
+	PanelID  int    `json:"panelId,omitempty"`
+	Panel    string `json:"panel,omitempty"`
+	Message  string `json:"message"`
+}
+
+// LintRuleConfig enables, disables or changes the severity of a rule, zero
+// values keep the default of the rule
+type LintRuleConfig struct {
+	Enabled  *bool  `json:"enabled,omitempty" yaml:"enabled"`
+	Severity string `json:"severity,omitempty" yaml:"severity"`
+}
+
+// LintConfig configures the rules by ID
+type LintConfig struct {
+	Rules map[string]LintRuleConfig `json:"rules" yaml:"rules"`
+}
+
+// lintRules are the built-in rules
+var lintRules = []LintRule{
+	{"dashboard-description", "Dashboards have a description", LintInfo, lintDashboardDescription},
+	{"panel-description", "Panels have a description", LintWarning, lintPanelDescription},
+	{"hardcoded-datasource", "Datasources are chosen with a template variable", LintWarning, lintHardcodedDatasource},
+	{"panel-unit", "Panels showing values have a unit", LintWarning, lintPanelUnit},
+	{"duplicate-panel-id", "Panel IDs are unique", LintError, lintDuplicatePanelID},
+	{"overlapping-panels", "Panels do not overlap", LintError, lintOverlappingPanels},
+}
+
+// LintRules returns the built-in rules with the config applied, disabled
+// rules are not returned
+func LintRules(config LintConfig) ([]LintRule, error) {
+	known := map[string]bool{}
+	rules := []LintRule{}
+	for _, rule := range lintRules {
+		known[rule.ID] = true
+		ruleConfig := config.Rules[rule.ID]
+		if ruleConfig.Enabled != nil && !*ruleConfig.Enabled {
+			continue
+		}
+		if ruleConfig.Severity != "" {
+			rule.Severity = ruleConfig.Severity
+		}
+		rules = append(rules, rule)
+	}
+
+	for id, ruleConfig := range config.Rules {
+		if !known[id] {
+			return nil, fmt.Errorf("unknown lint rule %s", id)
+		}
+		switch ruleConfig.Severity {
+		case "", LintError, LintWarning, LintInfo:
+		default:
+			return nil, fmt.Errorf("invalid severity %s of lint rule %s", ruleConfig.Severity, id)
+		}
+	}
+	return rules, nil
+}
+
+// Lint checks the dashboard against the rules. Rules are skipped if the
+// dashboard has the tag lint-ignore or lint-ignore:<rule>.
+func (d DashboardJSON) Lint(rules []LintRule) []LintProblem {
+	ignored := map[string]bool{}
+	for _, tag := range d.Tags {
+		if tag == LintIgnoreTag {
+			return []LintProblem{}
+		}
+		if strings.HasPrefix(tag, LintIgnoreTag+":") {
+			ignored[strings.TrimPrefix(tag, LintIgnoreTag+":")] = true
+		}
+	}
+
+	problems := []LintProblem{}
+	for _, rule := range rules {
+		if ignored[rule.ID] {
+			continue
+		}
+		for _, problem := range rule.check(d) {
+			problem.Rule = rule.ID
+			problem.Severity = rule.Severity
+			problems = append(problems, problem)
+		}
+	}
+	sort.SliceStable(problems, func(i, j int) bool {
+		return problems[i].PanelID < problems[j].PanelID
+	})
+	return problems
+}
+
+func lintDashboardDescription(d DashboardJSON) []LintProblem {
+	if strings.TrimSpace(d.Description) == "" {
+		return []LintProblem{{Message: "dashboard has no description"}}
+	}
+	return nil
+}
+
+func lintPanelDescription(d DashboardJSON) []LintProblem {
+	problems := []LintProblem{}
+	for _, panel := range d.AllPanels() {
+		if panel.Type == "row" || panel.Type == "text" || panel.LibraryPanel != nil {
+			continue
+		}
+		if strings.TrimSpace(panel.Description) == "" {
+			problems = append(problems, LintProblem{PanelID: panel.ID, Panel: panel.Title, Message: "panel has no description"})
+		}
+	}
+	return problems
+}
+
+func lintHardcodedDatasource(d DashboardJSON) []LintProblem {
+	problems := []LintProblem{}
+	check := func(ref interface{}, panelID int, panel string, where string) {
+		if name := hardcodedDatasource(ref); name != "" {
+			problems = append(problems, LintProblem{PanelID: panelID, Panel: panel, Message: fmt.Sprintf("%s uses datasource %s instead of a variable", where, name)})
+		}
+	}
+
+	for _, panel := range d.AllPanels() {
+		check(panel.Datasource, panel.ID, panel.Title, "panel")
+		for _, target := range panel.Targets {
+			check(target.Datasource, panel.ID, panel.Title, "query "+target.RefID)
+		}
+	}
+	for _, variable := range d.Templating.List {
+		check(variable.Datasource, 0, "", "variable "+variable.Name)
+	}
+	return problems
+}
+
+// hardcodedDatasource returns the name or UID of a datasource reference
+// which is not a template variable or a built-in datasource
+func hardcodedDatasource(ref interface{}) string {
+	var name string
+	switch value := ref.(type) {
+	case string:
+		name = value
+	case map[string]interface{}:
+		name, _ = value["uid"].(string)
+		if pluginType, _ := value["type"].(string); builtinDatasource(pluginType) {
+			return ""
+		}
+	}
+	if name == "" || strings.HasPrefix(name, "$") || builtinDatasource(name) {
+		return ""
+	}
+	return name
+}
+
+func lintPanelUnit(d DashboardJSON) []LintProblem {
+	problems := []LintProblem{}
+	for _, panel := range d.AllPanels() {
+		missing := false
+		switch panel.Type {
+		case "graph":
+			missing = true
+			for _, axis := range panel.Yaxes {
+				if axis.Show && axis.Format != "" && axis.Format != "short" {
+					missing = false
+				}
+			}
+		case "singlestat":
+			missing = panel.Format == "" || panel.Format == "none"
+		case "timeseries", "stat", "gauge", "bargauge", "barchart":
+			missing = panel.FieldConfig.Defaults.Unit == ""
+		}
+		if missing {
+			problems = append(problems, LintProblem{PanelID: panel.ID, Panel: panel.Title, Message: "panel has no unit"})
+		}
+	}
+	return problems
+}
+
+func lintDuplicatePanelID(d DashboardJSON) []LintProblem {
+	problems := []LintProblem{}
+	seen := map[int]bool{}
+	for _, panel := range d.AllPanels() {
+		if seen[panel.ID] {
+			problems = append(problems, LintProblem{PanelID: panel.ID, Panel: panel.Title, Message: fmt.Sprintf("panel ID %d is used more than once", panel.ID)})
+		}
+		seen[panel.ID] = true
+	}
+	return problems
+}
+
+// lintOverlappingPanels checks the top level panels and the panels of each
+// collapsed row on their own, collapsed panels are not shown together with
+// the top level panels
+func lintOverlappingPanels(d DashboardJSON) []LintProblem {
+	problems := lintOverlappingLevel(d)
+	for _, panel := range d.Panels {
+		problems = append(problems, lintOverlappingLevel(DashboardJSON{Panels: panel.Panels})...)
+	}
+	return problems
+}
+
+func lintOverlappingLevel(d DashboardJSON) []LintProblem {
+	problems := []LintProblem{}
+	for i, a := range d.Panels {
+		for _, b := range d.Panels[i+1:] {
+			if a.GridPos.X < b.GridPos.X+b.GridPos.W && b.GridPos.X < a.GridPos.X+a.GridPos.W &&
+				a.GridPos.Y < b.GridPos.Y+b.GridPos.H && b.GridPos.Y < a.GridPos.Y+a.GridPos.H {
+				problems = append(problems, LintProblem{PanelID: b.ID, Panel: b.Title, Message: fmt.Sprintf("panel overlaps panel %d %q", a.ID, a.Title)})
+			}
+		}
+	}
+	return problems
+}
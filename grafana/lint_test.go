@@ -0,0 +1,94 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package grafana_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/lstuker/grafana-tool/grafana"
+)
+
+const lintDashboard = `{"title":"Linux","description":"Linux hosts","tags":%s,
+	"templating":{"list":[{"name":"ds","type":"datasource"},{"name":"host","datasource":"${ds}"}]},
+	"panels":[
+		{"id":1,"type":"graph","title":"CPU","description":"CPU usage","datasource":"$ds",
+			"gridPos":{"x":0,"y":0,"w":12,"h":8},"yaxes":[{"format":"percent","show":true},{"format":"short","show":false}]},
+		{"id":2,"type":"timeseries","title":"Memory","datasource":{"type":"prometheus","uid":"prom1"},
+			"gridPos":{"x":6,"y":0,"w":12,"h":8},"fieldConfig":{"defaults":{}}},
+		{"id":3,"type":"row","collapsed":true,"gridPos":{"x":0,"y":8,"w":24,"h":1},"panels":[
+			{"id":2,"type":"stat","title":"Uptime","description":"Since boot","gridPos":{"x":0,"y":9,"w":6,"h":4},
+				"fieldConfig":{"defaults":{"unit":"s"}}}]}]}`
+
+func lintProblems(t *testing.T, tags string, config grafana.LintConfig) map[string]int {
+	var dashboard grafana.DashboardJSON
+	err := json.Unmarshal([]byte(fmt.Sprintf(lintDashboard, tags)), &dashboard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rules, err := grafana.LintRules(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	problems := map[string]int{}
+	for _, problem := range dashboard.Lint(rules) {
+		problems[problem.Rule+"/"+problem.Severity]++
+	}
+	return problems
+}
+
+func TestLint(t *testing.T) {
+	problems := lintProblems(t, `[]`, grafana.LintConfig{})
+	expect := map[string]int{
+		"panel-description/warning":    1,
+		"hardcoded-datasource/warning": 1,
+		"panel-unit/warning":           1,
+		"duplicate-panel-id/error":     1,
+		"overlapping-panels/error":     1,
+	}
+	if len(problems) != len(expect) {
+		t.Errorf("Is was  incorrect, got: %v, want: %v.", problems, expect)
+	}
+	for rule, count := range expect {
+		if problems[rule] != count {
+			t.Errorf("Is was  incorrect for %s, got: %d, want: %d.", rule, problems[rule], count)
+		}
+	}
+}
+
+func TestLintConfig(t *testing.T) {
+	disabled := false
+	config := grafana.LintConfig{Rules: map[string]grafana.LintRuleConfig{
+		"panel-unit":         {Enabled: &disabled},
+		"panel-description":  {Severity: grafana.LintError},
+		"overlapping-panels": {},
+	}}
+	problems := lintProblems(t, `["lint-ignore:hardcoded-datasource"]`, config)
+	if problems["panel-unit/warning"] != 0 || problems["hardcoded-datasource/warning"] != 0 {
+		t.Errorf("Expected disabled and ignored rules to be skipped, got: %v", problems)
+	}
+	if problems["panel-description/error"] != 1 {
+		t.Errorf("Expected the severity to be overridden, got: %v", problems)
+	}
+
+	if problems := lintProblems(t, `["lint-ignore"]`, grafana.LintConfig{}); len(problems) != 0 {
+		t.Errorf("Expected no problems with tag lint-ignore, got: %v", problems)
+	}
+
+	_, err := grafana.LintRules(grafana.LintConfig{Rules: map[string]grafana.LintRuleConfig{"unknown": {}}})
+	if err == nil {
+		t.Errorf("Expected an error for an unknown rule")
+	}
+}
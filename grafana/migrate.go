@@ -0,0 +1,344 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grafana
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// LatestSchemaVersion is the highest schema version MigrateDashboard
+// migrates to
+const LatestSchemaVersion = 36
+
+// migrationStep upgrades a dashboard to schema version Version and returns
+// notes about changes which need a review
+type migrationStep struct {
+	Version     int
+	Description string
+	apply       func(dashboard map[string]interface{}, datasources DatasourceListJSON) []string
+}
+
+// migrationSteps are the implemented steps ordered by version. A dashboard
+// is only migrated up to the first version without a step, Grafana skips
+// the migrations of every version below the schemaVersion of a dashboard.
+var migrationSteps = []migrationStep{
+	{16, "rows to row panels with grid positions", migrateRows},
+	{33, "panel and query datasource names to {type,uid} references", migratePanelDatasources},
+	{36, "variable and annotation datasource names to {type,uid} references", migrateVariableDatasources},
+}
+
+// unchangedSchemaVersions are the schema versions for which Grafana does not
+// migrate anything
+var unchangedSchemaVersions = map[int]bool{15: true}
+
+// MigrateDashboard upgrades the dashboard in place step by step from its
+// schemaVersion to the version to, or to the version before the first one
+// without a migration step. Grafana migrates the dashboard further from the
+// schemaVersion written. Datasource names are resolved with datasources to
+// references. Graph panels are converted to timeseries panels with
+// convertGraphs. It returns notes about every step and the changes which need
+// a review.
+func MigrateDashboard(dashboard map[string]interface{}, to int, datasources DatasourceListJSON, convertGraphs bool) ([]string, error) {
+	from := 0
+	if version, ok := dashboard["schemaVersion"].(float64); ok {
+		from = int(version)
+	}
+	if to > LatestSchemaVersion {
+		return nil, fmt.Errorf("schema version %d is not supported, the latest is %d", to, LatestSchemaVersion)
+	}
+	if from > to {
+		return nil, fmt.Errorf("dashboard has schema version %d, downgrades to %d are not possible", from, to)
+	}
+
+	notes := []string{}
+	reached := from
+	for version := from + 1; version <= to; version++ {
+		step, ok := migrationStepOf(version)
+		if !ok && !unchangedSchemaVersions[version] {
+			notes = append(notes, fmt.Sprintf("schema %d: no migration step, the dashboard keeps schema %d and Grafana migrates it further", version, reached))
+			break
+		}
+		reached = version
+		if !ok {
+			continue
+		}
+		notes = append(notes, fmt.Sprintf("schema %d: %s", step.Version, step.Description))
+		for _, note := range step.apply(dashboard, datasources) {
+			notes = append(notes, fmt.Sprintf("schema %d: %s", step.Version, note))
+		}
+	}
+	if convertGraphs {
+		notes = append(notes, "graph panels to timeseries panels")
+		notes = append(notes, migrateGraphPanels(dashboard)...)
+	} else {
+		graphs := 0
+		walkPanelMaps(dashboard, func(panel map[string]interface{}) {
+			if panel["type"] == "graph" {
+				graphs++
+			}
+		})
+		if graphs > 0 {
+			notes = append(notes, fmt.Sprintf("%d graph panels are kept, convert them with --convert-panels", graphs))
+		}
+	}
+
+	if reached > from {
+		dashboard["schemaVersion"] = reached
+	}
+	return notes, nil
+}
+
+// migrationStepOf returns the step which migrates to the schema version
+func migrationStepOf(version int) (migrationStep, bool) {
+	for _, step := range migrationSteps {
+		if step.Version == version {
+			return step, true
+		}
+	}
+	return migrationStep{}, false
+}
+
+// migrateRows replaces the rows of Grafana 4 with panels on the grid, the
+// panels of collapsed rows are moved into the row panel
+func migrateRows(dashboard map[string]interface{}, datasources DatasourceListJSON) []string {
+	rows, _ := dashboard["rows"].([]interface{})
+	delete(dashboard, "rows")
+	if len(rows) == 0 {
+		return nil
+	}
+
+	nextID := 1
+	showRows := len(rows) > 1
+	for _, item := range rows {
+		row, _ := item.(map[string]interface{})
+		if showTitle, _ := row["showTitle"].(bool); showTitle {
+			showRows = true
+		}
+		rowPanels, _ := row["panels"].([]interface{})
+		for _, item := range rowPanels {
+			panel, _ := item.(map[string]interface{})
+			if id, ok := panel["id"].(float64); ok && int(id) >= nextID {
+				nextID = int(id) + 1
+			}
+		}
+	}
+
+	panels, _ := dashboard["panels"].([]interface{})
+	y := 0
+	for _, item := range rows {
+		row, _ := item.(map[string]interface{})
+		collapsed, _ := row["collapse"].(bool)
+		height := gridHeight(row["height"], 250)
+
+		var rowPanel map[string]interface{}
+		if showRows {
+			rowPanel = map[string]interface{}{
+				"type":      "row",
+				"title":     row["title"],
+				"collapsed": collapsed,
+				"id":        nextID,
+				"gridPos":   map[string]interface{}{"x": 0, "y": y, "w": 24, "h": 1},
+				"panels":    []interface{}{},
+			}
+			if repeat, ok := row["repeat"]; ok {
+				rowPanel["repeat"] = repeat
+			}
+			nextID++
+			panels = append(panels, rowPanel)
+			y++
+		}
+
+		x := 0
+		rowY := y
+		rowPanels, _ := row["panels"].([]interface{})
+		for _, item := range rowPanels {
+			panel, _ := item.(map[string]interface{})
+			span, ok := panel["span"].(float64)
+			if !ok {
+				span = 4
+			}
+			w := int(math.Max(1, math.Min(24, math.Round(span*2))))
+			h := gridHeight(panel["height"], 0)
+			if h == 0 {
+				h = height
+			}
+			if x+w > 24 {
+				x = 0
+				rowY += height
+			}
+			panel["gridPos"] = map[string]interface{}{"x": x, "y": rowY, "w": w, "h": h}
+			delete(panel, "span")
+			delete(panel, "height")
+			x += w
+
+			if collapsed && rowPanel != nil {
+				rowPanel["panels"] = append(rowPanel["panels"].([]interface{}), panel)
+			} else {
+				panels = append(panels, panel)
+			}
+		}
+		if len(rowPanels) > 0 && !(collapsed && rowPanel != nil) {
+			y = rowY + height
+		}
+	}
+	dashboard["panels"] = panels
+	return nil
+}
+
+// gridHeight converts a height in pixels, ex: "250px" or 250, to grid
+// units of 30 pixels
+func gridHeight(value interface{}, fallback int) int {
+	pixels := float64(fallback)
+	switch height := value.(type) {
+	case float64:
+		pixels = height
+	case string:
+		if parsed, err := strconv.ParseFloat(strings.TrimSuffix(height, "px"), 64); err == nil {
+			pixels = parsed
+		}
+	}
+	return int(math.Ceil(pixels / 30))
+}
+
+// migratePanelDatasources replaces datasource names of panels and queries
+// with references
+func migratePanelDatasources(dashboard map[string]interface{}, datasources DatasourceListJSON) []string {
+	notes := []string{}
+	walkPanelMaps(dashboard, func(panel map[string]interface{}) {
+		migrateDatasourceField(panel, datasources, &notes)
+		targets, _ := panel["targets"].([]interface{})
+		for _, item := range targets {
+			if target, ok := item.(map[string]interface{}); ok {
+				migrateDatasourceField(target, datasources, &notes)
+			}
+		}
+	})
+	return notes
+}
+
+// migrateVariableDatasources replaces datasource names of template
+// variables and annotations with references
+func migrateVariableDatasources(dashboard map[string]interface{}, datasources DatasourceListJSON) []string {
+	notes := []string{}
+	for _, key := range []string{"templating", "annotations"} {
+		parent, _ := dashboard[key].(map[string]interface{})
+		list, _ := parent["list"].([]interface{})
+		for _, item := range list {
+			if object, ok := item.(map[string]interface{}); ok {
+				migrateDatasourceField(object, datasources, &notes)
+			}
+		}
+	}
+	return notes
+}
+
+// migrateGraphPanels converts graph panels to timeseries panels
+func migrateGraphPanels(dashboard map[string]interface{}) []string {
+	notes := []string{}
+	convertPanelMaps(dashboard, func(panel map[string]interface{}) map[string]interface{} {
+		if panel["type"] != "graph" {
+			return panel
+		}
+		converted, untranslated, _ := ConvertPanel(panel)
+		for _, option := range untranslated {
+			notes = append(notes, fmt.Sprintf("%s: not translated: %s", panelLabel(panel), option))
+		}
+		return converted
+	})
+	return notes
+}
+
+// panelLabel returns ID and title of a panel for notes, ex: panel 2 "CPU"
+func panelLabel(panel map[string]interface{}) string {
+	title, _ := panel["title"].(string)
+	return fmt.Sprintf("panel %v %q", panel["id"], title)
+}
+
+// migrateDatasourceField replaces a datasource name in object["datasource"]
+// with a reference
+func migrateDatasourceField(object map[string]interface{}, datasources DatasourceListJSON, notes *[]string) {
+	name, ok := object["datasource"].(string)
+	if !ok {
+		return
+	}
+	ref, found := DatasourceRef(name, datasources)
+	if ref == nil {
+		delete(object, "datasource")
+		return
+	}
+	if !found {
+		*notes = append(*notes, fmt.Sprintf("datasource %s not found, referenced by UID %s", name, name))
+	}
+	object["datasource"] = ref
+}
+
+// DatasourceRef returns the {type,uid} reference of a datasource name as
+// used since Grafana 8.3. Template variables and unknown names become a
+// reference by UID only, found is false for unknown names. The legacy name
+// default becomes the reference of the default datasource, without a known
+// default no reference is returned so Grafana uses its default.
+func DatasourceRef(name string, datasources DatasourceListJSON) (map[string]interface{}, bool) {
+	switch {
+	case name == "":
+		return nil, true
+	case strings.HasPrefix(name, "$"):
+		return map[string]interface{}{"uid": name}, true
+	case name == "-- Grafana --":
+		return map[string]interface{}{"type": "datasource", "uid": "grafana"}, true
+	case builtinDatasource(name):
+		return map[string]interface{}{"type": "datasource", "uid": name}, true
+	}
+	for _, datasource := range datasources {
+		if datasource.Name == name || datasource.UID == name {
+			return map[string]interface{}{"type": datasource.Type, "uid": datasource.UID}, true
+		}
+	}
+	if name == "default" {
+		for _, datasource := range datasources {
+			if datasource.IsDefault {
+				return map[string]interface{}{"type": datasource.Type, "uid": datasource.UID}, true
+			}
+		}
+		return nil, true
+	}
+	return map[string]interface{}{"uid": name}, false
+}
+
+// walkPanelMaps calls fn for every panel, including the panels of
+// collapsed rows
+func walkPanelMaps(parent map[string]interface{}, fn func(panel map[string]interface{})) {
+	convertPanelMaps(parent, func(panel map[string]interface{}) map[string]interface{} {
+		fn(panel)
+		return panel
+	})
+}
+
+// convertPanelMaps replaces every panel, including the panels of collapsed
+// rows, with the result of fn
+func convertPanelMaps(parent map[string]interface{}, fn func(panel map[string]interface{}) map[string]interface{}) {
+	panels, _ := parent["panels"].([]interface{})
+	for i, item := range panels {
+		panel, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		panel = fn(panel)
+		panels[i] = panel
+		convertPanelMaps(panel, fn)
+	}
+}
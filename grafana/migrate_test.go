@@ -0,0 +1,126 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package grafana_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/lstuker/grafana-tool/grafana"
+)
+
+func TestMigrateDashboard(t *testing.T) {
+	var dashboard map[string]interface{}
+	json.Unmarshal([]byte(`{"schemaVersion":14,"title":"Linux",
+		"templating":{"list":[{"name":"host","datasource":"influx"}]},
+		"rows":[
+			{"title":"CPU","height":"250px","panels":[
+				{"id":1,"type":"graph","span":6,"datasource":"influx","lines":true,"linewidth":2,"fill":1,
+					"targets":[{"refId":"A","datasource":"unknown"}]},
+				{"id":2,"type":"singlestat","span":6,"datasource":"$ds"}]},
+			{"title":"Memory","collapse":true,"panels":[{"id":3,"type":"text","span":12}]}]}`), &dashboard)
+	datasources := grafana.DatasourceListJSON{{Name: "influx", UID: "influx1", Type: "influxdb"}}
+
+	notes, err := grafana.MigrateDashboard(dashboard, grafana.LatestSchemaVersion, datasources, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := json.Marshal(dashboard)
+	var got map[string]interface{}
+	json.Unmarshal(data, &got)
+
+	// schema 17 has no step, Grafana has to migrate the singlestat panel
+	var expect map[string]interface{}
+	json.Unmarshal([]byte(`{"schemaVersion":16,"title":"Linux",
+		"templating":{"list":[{"name":"host","datasource":"influx"}]},
+		"panels":[
+			{"id":4,"type":"row","title":"CPU","collapsed":false,"panels":[],"gridPos":{"x":0,"y":0,"w":24,"h":1}},
+			{"id":1,"type":"graph","datasource":"influx","gridPos":{"x":0,"y":1,"w":12,"h":9},
+				"lines":true,"linewidth":2,"fill":1,
+				"targets":[{"refId":"A","datasource":"unknown"}]},
+			{"id":2,"type":"singlestat","datasource":"$ds","gridPos":{"x":12,"y":1,"w":12,"h":9}},
+			{"id":5,"type":"row","title":"Memory","collapsed":true,"gridPos":{"x":0,"y":10,"w":24,"h":1},"panels":[
+				{"id":3,"type":"text","gridPos":{"x":0,"y":11,"w":24,"h":9}}]}]}`), &expect)
+
+	if changes := grafana.DiffJSON(expect, got); len(changes) > 0 {
+		for _, change := range changes {
+			t.Errorf("Is was  incorrect: %s", change)
+		}
+	}
+	if len(notes) != 3 {
+		t.Errorf("Expected 3 notes, got: %v", notes)
+	}
+}
+
+func TestMigrateDashboardDatasources(t *testing.T) {
+	datasources := grafana.DatasourceListJSON{
+		{Name: "influx", UID: "influx1", Type: "influxdb"},
+		{Name: "prom", UID: "prom1", Type: "prometheus", IsDefault: true},
+	}
+	tables := []struct {
+		dashboard string
+		expect    string
+	}{
+		{`{"schemaVersion":32,"panels":[{"id":1,"datasource":"influx","targets":[{"refId":"A","datasource":"unknown"}]},
+			{"id":2,"datasource":"default"}],"templating":{"list":[{"name":"host","datasource":"influx"}]}}`,
+			`{"schemaVersion":33,"panels":[{"id":1,"datasource":{"type":"influxdb","uid":"influx1"},
+			"targets":[{"refId":"A","datasource":{"uid":"unknown"}}]},{"id":2,"datasource":{"type":"prometheus","uid":"prom1"}}],
+			"templating":{"list":[{"name":"host","datasource":"influx"}]}}`},
+		{`{"schemaVersion":35,"templating":{"list":[{"name":"host","datasource":"influx"},{"name":"job","datasource":"default"}]}}`,
+			`{"schemaVersion":36,"templating":{"list":[{"name":"host","datasource":{"type":"influxdb","uid":"influx1"}},
+			{"name":"job","datasource":{"type":"prometheus","uid":"prom1"}}]}}`},
+	}
+	for _, table := range tables {
+		var dashboard, expect map[string]interface{}
+		json.Unmarshal([]byte(table.dashboard), &dashboard)
+		json.Unmarshal([]byte(table.expect), &expect)
+		if _, err := grafana.MigrateDashboard(dashboard, grafana.LatestSchemaVersion, datasources, false); err != nil {
+			t.Fatal(err)
+		}
+		data, _ := json.Marshal(dashboard)
+		var got map[string]interface{}
+		json.Unmarshal(data, &got)
+		if changes := grafana.DiffJSON(expect, got); len(changes) > 0 {
+			for _, change := range changes {
+				t.Errorf("Is was  incorrect: %s", change)
+			}
+		}
+	}
+}
+
+func TestMigrateDashboardDowngrade(t *testing.T) {
+	dashboard := map[string]interface{}{"schemaVersion": float64(30)}
+	if _, err := grafana.MigrateDashboard(dashboard, 16, nil, false); err == nil {
+		t.Errorf("Expected an error for a downgrade")
+	}
+}
+
+func TestMigrateDashboardConvertPanels(t *testing.T) {
+	var dashboard map[string]interface{}
+	json.Unmarshal([]byte(`{"schemaVersion":36,"panels":[{"id":1,"type":"graph","lines":true,"linewidth":2,"fill":1},
+		{"id":2,"type":"singlestat"}]}`), &dashboard)
+
+	notes, err := grafana.MigrateDashboard(dashboard, grafana.LatestSchemaVersion, nil, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	panels := dashboard["panels"].([]interface{})
+	types := []interface{}{panels[0].(map[string]interface{})["type"], panels[1].(map[string]interface{})["type"]}
+	if types[0] != "timeseries" || types[1] != "singlestat" {
+		t.Errorf("Is was  incorrect, got: %v, want: %v.", types, []string{"timeseries", "singlestat"})
+	}
+	if len(notes) == 0 || notes[0] != "graph panels to timeseries panels" {
+		t.Errorf("Is was  incorrect, got: %v, want: %v.", notes, "graph panels to timeseries panels")
+	}
+}
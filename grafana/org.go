@@ -0,0 +1,139 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grafana
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// OrgJSON is an organisation from the Grafana API
+// More info: https://grafana.com/docs/http_api/org/
+type OrgJSON struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// GetCurrentOrg returns the organisation of the authenticated user or token.
+// It reflects GET /api/org API call.
+// More info: https://grafana.com/docs/http_api/org/
+func (r *Client) GetCurrentOrg() (OrgJSON, error) {
+	var (
+		record OrgJSON
+		raw    []byte
+		code   int
+		err    error
+	)
+
+	raw, code, err = r.getRequest("/api/org", nil)
+
+	if err != nil && code != 200 {
+		return record, err
+	}
+	if code != 200 {
+		return record, fmt.Errorf("HTTP error %d: returns %s", code, raw)
+	}
+
+	err = json.Unmarshal(raw, &record)
+	return record, err
+}
+
+// OrgListJSON is a list of organisations from the Grafana API
+// More info: https://grafana.com/docs/http_api/org/
+type OrgListJSON []OrgJSON
+
+// GetOrgs returns all organisations, it needs admin permissions.
+// It reflects GET /api/orgs API call.
+// More info: https://grafana.com/docs/http_api/org/
+func (r *Client) GetOrgs() (OrgListJSON, error) {
+	var (
+		records OrgListJSON
+		raw     []byte
+		code    int
+		err     error
+	)
+
+	q := url.Values{}
+	q.Set("perpage", "10000")
+
+	raw, code, err = r.getRequest("/api/orgs", q)
+
+	if err != nil && code != 200 {
+		return records, err
+	}
+	if code != 200 {
+		return nil, fmt.Errorf("HTTP error %d: returns %s", code, raw)
+	}
+
+	err = json.Unmarshal(raw, &records)
+	return records, err
+}
+
+// CreateOrg creates an organisation and returns its ID. The authenticated
+// user becomes admin of the new organisation.
+// It reflects POST /api/orgs API call.
+// More info: https://grafana.com/docs/http_api/org/
+func (r *Client) CreateOrg(name string) (int, error) {
+	body, err := json.Marshal(map[string]string{"name": name})
+	if err != nil {
+		return 0, err
+	}
+
+	raw, code, err := r.postRequest("/api/orgs", nil, body)
+
+	if err != nil && code != 200 {
+		return 0, err
+	}
+	if code != 200 {
+		return 0, fmt.Errorf("HTTP error %d: returns %s", code, raw)
+	}
+
+	result := struct {
+		OrgID int `json:"orgId"`
+	}{}
+	err = json.Unmarshal(raw, &result)
+	return result.OrgID, err
+}
+
+// DeleteOrg deletes the organisation with the given ID, it needs admin permissions.
+// It reflects DELETE /api/orgs/:orgId API call.
+// More info: https://grafana.com/docs/http_api/org/
+func (r *Client) DeleteOrg(ID int) error {
+	raw, code, err := r.deleteRequest(fmt.Sprintf("/api/orgs/%d", ID))
+
+	if err != nil && code != 200 {
+		return err
+	}
+	if code != 200 {
+		return fmt.Errorf("HTTP error %d: returns %s", code, raw)
+	}
+	return nil
+}
+
+// OrgFind search in a OrgListJSON the organisation by name or ID and
+// returns the OrgJSON object
+func (o OrgListJSON) OrgFind(nameOrID string) (OrgJSON, error) {
+	var empty OrgJSON
+
+	for _, org := range o {
+		if org.Name == nameOrID || strconv.Itoa(org.ID) == nameOrID {
+			return org, nil
+		}
+	}
+	return empty, errors.New("Organisation not found")
+}
@@ -0,0 +1,43 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package grafana_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/lstuker/grafana-tool/grafana"
+)
+
+func TestOrgFind(t *testing.T) {
+	var orgs grafana.OrgListJSON
+	orgs_str := []byte(`[{"id":1,"name":"Main Org."},{"id":4,"name":"template"}]`)
+	json.Unmarshal(orgs_str, &orgs)
+
+	tables := []struct {
+		nameOrID string
+		expect   int
+	}{
+		{"template", 4},
+		{"1", 1},
+		{"Main Org.", 1},
+	}
+
+	for _, table := range tables {
+		org, err := orgs.OrgFind(table.nameOrID)
+		if err != nil || org.ID != table.expect {
+			t.Errorf("Is was  incorrect, got: %d, want: %d.", org.ID, table.expect)
+		}
+	}
+}
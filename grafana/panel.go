@@ -0,0 +1,509 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grafana
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Panel is a panel of a dashboard, rows are panels of type row. Options of
+// plugins the type does not model are kept in Extra and written back by
+// MarshalJSON, see RawOption and SetRawOption.
+// more info: https://grafana.com/docs/reference/dashboard/
+type Panel struct {
+	AliasColors map[string]string `json:"aliasColors,omitempty"`
+	Bars        bool              `json:"bars,omitempty"`
+	DashLength  int               `json:"dashLength,omitempty"`
+	Dashes      bool              `json:"dashes,omitempty"`
+	Datasource  interface{}       `json:"datasource,omitempty"`
+	Fill        int               `json:"fill,omitempty"`
+	GridPos     struct {
+		H int `json:"h"`
+		W int `json:"w"`
+		X int `json:"x"`
+		Y int `json:"y"`
+	} `json:"gridPos"`
+	ID     int `json:"id"`
+	Legend struct {
+		Avg          bool `json:"avg"`
+		Current      bool `json:"current"`
+		Max          bool `json:"max"`
+		Min          bool `json:"min"`
+		Show         bool `json:"show"`
+		Total        bool `json:"total"`
+		Values       bool `json:"values"`
+		AlignAsTable bool `json:"alignAsTable,omitempty"`
+		RightSide    bool `json:"rightSide,omitempty"`
+	} `json:"legend,omitempty"`
+	Lines           bool            `json:"lines,omitempty"`
+	Linewidth       int             `json:"linewidth,omitempty"`
+	Links           []interface{}   `json:"links,omitempty"`
+	NullPointMode   string          `json:"nullPointMode,omitempty"`
+	Percentage      bool            `json:"percentage,omitempty"`
+	Pointradius     float32         `json:"pointradius,omitempty"`
+	Points          bool            `json:"points,omitempty"`
+	Renderer        string          `json:"renderer,omitempty"`
+	SeriesOverrides []interface{}   `json:"seriesOverrides,omitempty"`
+	SpaceLength     int             `json:"spaceLength,omitempty"`
+	Stack           bool            `json:"stack,omitempty"`
+	SteppedLine     bool            `json:"steppedLine,omitempty"`
+	Targets         []Target        `json:"targets,omitempty"`
+	Thresholds      json.RawMessage `json:"thresholds,omitempty"`
+	TimeFrom        interface{}     `json:"timeFrom,omitempty"`
+	TimeShift       interface{}     `json:"timeShift,omitempty"`
+	Title           string          `json:"title"`
+	Tooltip         struct {
+		Shared    bool   `json:"shared"`
+		Sort      int    `json:"sort"`
+		ValueType string `json:"value_type"`
+	} `json:"tooltip,omitempty"`
+	Type  string `json:"type"`
+	Xaxis struct {
+		Buckets interface{}   `json:"buckets"`
+		Mode    string        `json:"mode"`
+		Name    interface{}   `json:"name"`
+		Show    bool          `json:"show"`
+		Values  []interface{} `json:"values"`
+	} `json:"xaxis,omitempty"`
+	Yaxes []struct {
+		Format  string      `json:"format"`
+		Label   interface{} `json:"label"`
+		LogBase int         `json:"logBase"`
+		Max     interface{} `json:"max"`
+		Min     interface{} `json:"min"`
+		Show    bool        `json:"show"`
+	} `json:"yaxes,omitempty"`
+	Yaxis struct {
+		Align      bool        `json:"align"`
+		AlignLevel interface{} `json:"alignLevel"`
+	} `json:"yaxis,omitempty"`
+	Description     string                 `json:"description,omitempty"`
+	Collapsed       bool                   `json:"collapsed,omitempty"`
+	Panels          []Panel                `json:"panels,omitempty"`
+	Repeat          string                 `json:"repeat,omitempty"`
+	LibraryPanel    *LibraryPanel          `json:"libraryPanel,omitempty"`
+	ScopedVars      map[string]interface{} `json:"scopedVars,omitempty"`
+	CacheTimeout    interface{}            `json:"cacheTimeout,omitempty"`
+	ColorBackground bool                   `json:"colorBackground,omitempty"`
+	ColorValue      bool                   `json:"colorValue,omitempty"`
+	Colors          []string               `json:"colors,omitempty"`
+	Decimals        int                    `json:"decimals,omitempty"`
+	Format          string                 `json:"format,omitempty"`
+	Gauge           struct {
+		MaxValue         int  `json:"maxValue"`
+		MinValue         int  `json:"minValue"`
+		Show             bool `json:"show"`
+		ThresholdLabels  bool `json:"thresholdLabels"`
+		ThresholdMarkers bool `json:"thresholdMarkers"`
+	} `json:"gauge,omitempty"`
+	Interval     interface{} `json:"interval,omitempty"`
+	MappingType  int         `json:"mappingType,omitempty"`
+	MappingTypes []struct {
+		Name  string `json:"name"`
+		Value int    `json:"value"`
+	} `json:"mappingTypes,omitempty"`
+	MaxDataPoints   int         `json:"maxDataPoints,omitempty"`
+	NullText        interface{} `json:"nullText,omitempty"`
+	Postfix         string      `json:"postfix,omitempty"`
+	PostfixFontSize string      `json:"postfixFontSize,omitempty"`
+	Prefix          string      `json:"prefix,omitempty"`
+	PrefixFontSize  string      `json:"prefixFontSize,omitempty"`
+	RangeMaps       []struct {
+		From string `json:"from"`
+		Text string `json:"text"`
+		To   string `json:"to"`
+	} `json:"rangeMaps,omitempty"`
+	Sparkline struct {
+		FillColor string `json:"fillColor"`
+		Full      bool   `json:"full"`
+		LineColor string `json:"lineColor"`
+		Show      bool   `json:"show"`
+	} `json:"sparkline,omitempty"`
+	FieldConfig     FieldConfig            `json:"fieldConfig,omitempty"`
+	Options         map[string]interface{} `json:"options,omitempty"`
+	Transformations []Transformation       `json:"transformations,omitempty"`
+	PluginVersion   string                 `json:"pluginVersion,omitempty"`
+	TableColumn     string                 `json:"tableColumn,omitempty"`
+	ValueFontSize   string                 `json:"valueFontSize,omitempty"`
+	ValueMaps       []struct {
+		Op    string `json:"op"`
+		Text  string `json:"text"`
+		Value string `json:"value"`
+	} `json:"valueMaps,omitempty"`
+	ValueName string `json:"valueName,omitempty"`
+
+	// Extra are the keys of the panel JSON the type does not model
+	Extra map[string]json.RawMessage `json:"-"`
+	raw   json.RawMessage
+}
+
+// Target is a query of a panel. The keys of the query editors the type does
+// not model are kept in Extra, EffectiveQuery returns the query text of
+// every datasource type.
+type Target struct {
+	Alias   string `json:"alias"`
+	GroupBy []struct {
+		Params []interface{} `json:"params"`
+		Type   string        `json:"type"`
+	} `json:"groupBy"`
+	Measurement  string `json:"measurement"`
+	OrderByTime  string `json:"orderByTime"`
+	Policy       string `json:"policy"`
+	Query        string `json:"query"`
+	RawQuery     bool   `json:"rawQuery"`
+	RefID        string `json:"refId"`
+	ResultFormat string `json:"resultFormat"`
+	Select       [][]struct {
+		Params []interface{} `json:"params"`
+		Type   string        `json:"type"`
+	} `json:"select"`
+	Tags       []interface{} `json:"tags"`
+	Datasource interface{}   `json:"datasource,omitempty"`
+	Hide       bool          `json:"hide,omitempty"`
+	Fill       string        `json:"fill,omitempty"`
+	Limit      interface{}   `json:"limit,omitempty"`
+	SLimit     interface{}   `json:"slimit,omitempty"`
+	Tz         string        `json:"tz,omitempty"`
+
+	Expr   string `json:"expr,omitempty"`
+	RawSQL string `json:"rawSql,omitempty"`
+
+	Namespace     string                 `json:"namespace,omitempty"`
+	MetricName    string                 `json:"metricName,omitempty"`
+	Dimensions    map[string]interface{} `json:"dimensions,omitempty"`
+	Statistic     string                 `json:"statistic,omitempty"`
+	Statistics    []string               `json:"statistics,omitempty"`
+	Region        string                 `json:"region,omitempty"`
+	Expression    string                 `json:"expression,omitempty"`
+	SQLExpression string                 `json:"sqlExpression,omitempty"`
+
+	// Extra are the keys of the target JSON the type does not model
+	Extra map[string]json.RawMessage `json:"-"`
+	raw   json.RawMessage
+}
+
+// FieldConfig are the field options of panels since Grafana 7
+// more info: https://grafana.com/docs/panels/field-options/
+type FieldConfig struct {
+	Defaults  FieldDefaults `json:"defaults"`
+	Overrides []Override    `json:"overrides"`
+}
+
+// FieldDefaults are the field options of all fields of a panel, the options
+// of the panel plugin are in Custom
+type FieldDefaults struct {
+	Unit        string                 `json:"unit,omitempty"`
+	Decimals    *int                   `json:"decimals,omitempty"`
+	Min         *float64               `json:"min,omitempty"`
+	Max         *float64               `json:"max,omitempty"`
+	DisplayName string                 `json:"displayName,omitempty"`
+	NoValue     string                 `json:"noValue,omitempty"`
+	Color       map[string]interface{} `json:"color,omitempty"`
+	Thresholds  *Thresholds            `json:"thresholds,omitempty"`
+	Mappings    []interface{}          `json:"mappings,omitempty"`
+	Links       []interface{}          `json:"links,omitempty"`
+	Custom      map[string]interface{} `json:"custom,omitempty"`
+
+	// Extra are the keys of the defaults JSON the type does not model
+	Extra map[string]json.RawMessage `json:"-"`
+	raw   json.RawMessage
+}
+
+// Thresholds are the threshold steps of a field, the first step has no value
+type Thresholds struct {
+	Mode  string `json:"mode"`
+	Steps []struct {
+		Color string   `json:"color"`
+		Value *float64 `json:"value"`
+	} `json:"steps"`
+}
+
+// Override are field options for the fields the matcher selects
+type Override struct {
+	Matcher struct {
+		ID      string      `json:"id"`
+		Options interface{} `json:"options"`
+	} `json:"matcher"`
+	Properties []struct {
+		ID    string      `json:"id"`
+		Value interface{} `json:"value"`
+	} `json:"properties"`
+}
+
+// Transformation transforms the query results of a panel
+// more info: https://grafana.com/docs/panels/transformations/
+type Transformation struct {
+	ID       string                 `json:"id"`
+	Options  map[string]interface{} `json:"options"`
+	Disabled bool                   `json:"disabled,omitempty"`
+	Filter   interface{}            `json:"filter,omitempty"`
+}
+
+// RowPanel is a row of a dashboard with its panels, the panels of an
+// expanded row are the top level panels up to the next row. Row is nil for
+// the panels before the first row.
+type RowPanel struct {
+	Row    *Panel
+	Panels []*Panel
+}
+
+// Rows returns the rows of the dashboard, expanded and collapsed
+func (d *DashboardJSON) Rows() []RowPanel {
+	rows := []RowPanel{}
+	current := RowPanel{}
+	for i := range d.Panels {
+		panel := &d.Panels[i]
+		if panel.Type != "row" {
+			current.Panels = append(current.Panels, panel)
+			continue
+		}
+		if current.Row != nil || len(current.Panels) > 0 {
+			rows = append(rows, current)
+		}
+		current = RowPanel{Row: panel}
+		for j := range panel.Panels {
+			current.Panels = append(current.Panels, &panel.Panels[j])
+		}
+	}
+	if current.Row != nil || len(current.Panels) > 0 {
+		rows = append(rows, current)
+	}
+	return rows
+}
+
+// WalkPanels calls fn for every panel of the dashboard, including the rows
+// and the panels of collapsed rows, with the row of the panel or nil. The
+// panels can be modified by fn.
+func (d *DashboardJSON) WalkPanels(fn func(panel *Panel, row *Panel)) {
+	for _, row := range d.Rows() {
+		if row.Row != nil {
+			fn(row.Row, nil)
+		}
+		for _, panel := range row.Panels {
+			fn(panel, row.Row)
+		}
+	}
+}
+
+// AllPanels returns all panels of the dashboard, including the rows and the
+// panels of collapsed rows
+func (d *DashboardJSON) AllPanels() []*Panel {
+	panels := []*Panel{}
+	d.WalkPanels(func(panel *Panel, row *Panel) {
+		panels = append(panels, panel)
+	})
+	return panels
+}
+
+// RawOption returns an option of the panel plugin the Panel type does not
+// model, ex: the options of a plugin installed from grafana.com
+func (p Panel) RawOption(key string) (json.RawMessage, bool) {
+	value, ok := p.Extra[key]
+	return value, ok
+}
+
+// SetRawOption sets an option of the panel plugin the Panel type does not
+// model
+func (p *Panel) SetRawOption(key string, value interface{}) error {
+	if jsonKeys(reflect.TypeOf(panelJSON{}))[key] {
+		return fmt.Errorf("option %s is a field of Panel", key)
+	}
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	if p.Extra == nil {
+		p.Extra = map[string]json.RawMessage{}
+	}
+	p.Extra[key] = raw
+	return nil
+}
+
+type panelJSON Panel
+type targetJSON Target
+type fieldDefaultsJSON FieldDefaults
+
+// UnmarshalJSON decodes a panel and keeps the keys it does not model in
+// Extra. Values with an unexpected type are left empty and do not stop
+// decoding the other panels, MarshalJSON writes them back unchanged.
+func (p *Panel) UnmarshalJSON(data []byte) error {
+	var typed panelJSON
+	extra, raw, err := splitJSON(data, &typed)
+	*p = Panel(typed)
+	p.Extra, p.raw = extra, raw
+	return err
+}
+
+// MarshalJSON encodes a panel with the keys of Extra, unchanged fields are
+// written as they were decoded
+func (p Panel) MarshalJSON() ([]byte, error) {
+	var original panelJSON
+	if p.raw != nil {
+		json.Unmarshal(p.raw, &original)
+	}
+	return joinJSON(panelJSON(p), original, p.Extra, p.raw)
+}
+
+// UnmarshalJSON decodes a target and keeps the keys it does not model in
+// Extra
+func (t *Target) UnmarshalJSON(data []byte) error {
+	var typed targetJSON
+	extra, raw, err := splitJSON(data, &typed)
+	*t = Target(typed)
+	t.Extra, t.raw = extra, raw
+	return err
+}
+
+// MarshalJSON encodes a target with the keys of Extra, unchanged fields are
+// written as they were decoded
+func (t Target) MarshalJSON() ([]byte, error) {
+	var original targetJSON
+	if t.raw != nil {
+		json.Unmarshal(t.raw, &original)
+	}
+	return joinJSON(targetJSON(t), original, t.Extra, t.raw)
+}
+
+// UnmarshalJSON decodes field defaults and keeps the keys it does not model
+// in Extra
+func (f *FieldDefaults) UnmarshalJSON(data []byte) error {
+	var typed fieldDefaultsJSON
+	extra, raw, err := splitJSON(data, &typed)
+	*f = FieldDefaults(typed)
+	f.Extra, f.raw = extra, raw
+	return err
+}
+
+// MarshalJSON encodes field defaults with the keys of Extra, unchanged
+// fields are written as they were decoded
+func (f FieldDefaults) MarshalJSON() ([]byte, error) {
+	var original fieldDefaultsJSON
+	if f.raw != nil {
+		json.Unmarshal(f.raw, &original)
+	}
+	return joinJSON(fieldDefaultsJSON(f), original, f.Extra, f.raw)
+}
+
+// splitJSON decodes the object data into typed and returns the keys typed
+// does not model and a copy of data. Type errors are ignored like for the
+// anonymous structs of the other types, so one odd value does not stop
+// decoding the dashboard.
+func splitJSON(data []byte, typed interface{}) (map[string]json.RawMessage, json.RawMessage, error) {
+	var object map[string]json.RawMessage
+	err := json.Unmarshal(data, &object)
+	if err != nil {
+		return nil, nil, err
+	}
+	err = json.Unmarshal(data, typed)
+	if _, ok := err.(*json.UnmarshalTypeError); ok {
+		err = nil
+	}
+
+	known := jsonKeys(reflect.TypeOf(typed).Elem())
+	var extra map[string]json.RawMessage
+	for key, value := range object {
+		if known[key] {
+			continue
+		}
+		if extra == nil {
+			extra = map[string]json.RawMessage{}
+		}
+		extra[key] = value
+	}
+	return extra, append(json.RawMessage{}, data...), err
+}
+
+// joinJSON encodes typed with the keys of extra. original is raw decoded
+// again, a field typed did not change is written with its raw value, so
+// zero values like "lines":false, values of an unexpected type and nested
+// keys the type does not model are kept. Zero fields not in raw are left
+// out, so a panel does not get the options of other panel types.
+func joinJSON(typed interface{}, original interface{}, extra map[string]json.RawMessage, raw json.RawMessage) ([]byte, error) {
+	object, err := jsonObject(typed)
+	if err != nil {
+		return nil, err
+	}
+	originalObject, err := jsonObject(original)
+	if err != nil {
+		return nil, err
+	}
+	var rawObject map[string]json.RawMessage
+	if raw != nil {
+		json.Unmarshal(raw, &rawObject)
+	}
+
+	for key, value := range object {
+		if !bytes.Equal(value, originalObject[key]) {
+			continue
+		}
+		if rawValue, ok := rawObject[key]; ok {
+			object[key] = rawValue
+		} else {
+			delete(object, key)
+		}
+	}
+	known := jsonKeys(reflect.TypeOf(typed))
+	for key, rawValue := range rawObject {
+		_, encoded := object[key]
+		_, decoded := originalObject[key]
+		if known[key] && !encoded && !decoded {
+			object[key] = rawValue
+		}
+	}
+	for key, value := range extra {
+		if _, ok := object[key]; !ok {
+			object[key] = value
+		}
+	}
+	return json.Marshal(object)
+}
+
+// jsonObject encodes v and returns its keys with the encoded values
+func jsonObject(v interface{}) (map[string]json.RawMessage, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var object map[string]json.RawMessage
+	err = json.Unmarshal(data, &object)
+	return object, err
+}
+
+// jsonKeys returns the JSON keys of the fields of a struct type
+func jsonKeys(structType reflect.Type) map[string]bool {
+	keys := map[string]bool{}
+	for i := 0; i < structType.NumField(); i++ {
+		name := strings.Split(structType.Field(i).Tag.Get("json"), ",")[0]
+		if name != "" && name != "-" {
+			keys[name] = true
+		}
+	}
+	return keys
+}
+
+// typedPanel returns the typed form of a raw panel
+func typedPanel(panel map[string]interface{}) Panel {
+	var typed Panel
+	raw, err := json.Marshal(panel)
+	if err != nil {
+		return typed
+	}
+	json.Unmarshal(raw, &typed)
+	return typed
+}
@@ -0,0 +1,126 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package grafana_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/lstuker/grafana-tool/grafana"
+)
+
+func TestDashboardPanels(t *testing.T) {
+	var dashboard grafana.DashboardJSON
+	err := json.Unmarshal([]byte(`{"title":"Linux","panels":[
+		{"id":1,"type":"stat","title":"Uptime"},
+		{"id":2,"type":"row","title":"CPU","collapsed":false},
+		{"id":3,"type":"timeseries","title":"Load","fieldConfig":{"defaults":{"unit":"short","filterable":true},"overrides":[]}},
+		{"id":4,"type":"row","title":"Disk","collapsed":true,"panels":[
+			{"id":5,"type":"grafana-piechart-panel","title":"Usage","pieType":"donut",
+				"targets":[{"refId":"A","expr":"node_filesystem_free_bytes"}]}]}]}`), &dashboard)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ids := []int{}
+	rows := map[int]int{}
+	dashboard.WalkPanels(func(panel *grafana.Panel, row *grafana.Panel) {
+		ids = append(ids, panel.ID)
+		if row != nil {
+			rows[panel.ID] = row.ID
+		}
+	})
+	if len(ids) != 5 || ids[4] != 5 {
+		t.Errorf("Is was  incorrect, got: %v, want: %v.", ids, []int{1, 2, 3, 4, 5})
+	}
+	if rows[3] != 2 || rows[5] != 4 || rows[1] != 0 {
+		t.Errorf("Is was  incorrect, got: %v, want: %v.", rows, map[int]int{3: 2, 5: 4})
+	}
+	if got := len(dashboard.Rows()); got != 3 {
+		t.Errorf("Is was  incorrect, got: %v, want: %v.", got, 3)
+	}
+
+	piechart := dashboard.AllPanels()[4]
+	option, ok := piechart.RawOption("pieType")
+	if !ok || string(option) != `"donut"` {
+		t.Errorf("Is was  incorrect, got: %s, want: %v.", option, `"donut"`)
+	}
+	if err := piechart.SetRawOption("legendType", "Right side"); err != nil {
+		t.Error(err)
+	}
+	if err := piechart.SetRawOption("title", "Usage"); err == nil {
+		t.Errorf("Expected an error for an option modeled by Panel")
+	}
+	dashboard.AllPanels()[2].FieldConfig.Defaults.Unit = "percent"
+
+	data, err := json.Marshal(dashboard.Panels)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got, expect interface{}
+	json.Unmarshal(data, &got)
+	json.Unmarshal([]byte(`[
+		{"id":1,"type":"stat","title":"Uptime"},
+		{"id":2,"type":"row","title":"CPU","collapsed":false},
+		{"id":3,"type":"timeseries","title":"Load",
+			"fieldConfig":{"defaults":{"unit":"percent","filterable":true},"overrides":[]}},
+		{"id":4,"type":"row","title":"Disk","collapsed":true,"panels":[
+			{"id":5,"type":"grafana-piechart-panel","title":"Usage",
+				"pieType":"donut","legendType":"Right side",
+				"targets":[{"refId":"A","expr":"node_filesystem_free_bytes"}]}]}]`), &expect)
+	for _, change := range grafana.DiffJSON(expect, got) {
+		t.Errorf("Is was  incorrect: %s", change)
+	}
+}
+
+func TestPanelRoundTrip(t *testing.T) {
+	input := `{"id":1,"type":"graph","title":"CPU","lines":false,"fill":0,"linewidth":0,"decimals":0,
+		"maxDataPoints":"100","gauge":{"maxValue":1.5,"show":false},
+		"legend":{"show":true,"sort":"max","sortDesc":true},
+		"fieldConfig":{"defaults":{"decimals":0,"min":"auto"},"overrides":[]},
+		"targets":[{"refId":"A","hide":false,"rawQuery":false,"expr":"up"}]}`
+	var panel grafana.Panel
+	if err := json.Unmarshal([]byte(input), &panel); err != nil {
+		t.Fatal(err)
+	}
+
+	var expect, got interface{}
+	json.Unmarshal([]byte(input), &expect)
+	data, err := json.Marshal(panel)
+	if err != nil {
+		t.Fatal(err)
+	}
+	json.Unmarshal(data, &got)
+	for _, change := range grafana.DiffJSON(expect, got) {
+		t.Errorf("Is was  incorrect: %s", change)
+	}
+
+	panel.Fill = 2
+	panel.Lines = true
+	panel.Title = "Load"
+	json.Unmarshal([]byte(input), &expect)
+	expectPanel := expect.(map[string]interface{})
+	expectPanel["fill"] = float64(2)
+	expectPanel["lines"] = true
+	expectPanel["title"] = "Load"
+	data, err = json.Marshal(panel)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got = nil
+	json.Unmarshal(data, &got)
+	for _, change := range grafana.DiffJSON(expect, got) {
+		t.Errorf("Is was  incorrect: %s", change)
+	}
+}
@@ -0,0 +1,694 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grafana
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// panelConverter converts a panel of a deprecated type to its successor and
+// returns the options which could not be translated
+type panelConverter func(panel map[string]interface{}) (map[string]interface{}, []string)
+
+// panelConverters are the converters by the deprecated panel type
+var panelConverters = map[string]panelConverter{
+	"graph":                  convertGraphPanel,
+	"singlestat":             convertSinglestatPanel,
+	"table-old":              convertTablePanel,
+	"grafana-worldmap-panel": convertWorldmapPanel,
+}
+
+// DeprecatedPanelTypes returns the panel types ConvertPanel converts
+func DeprecatedPanelTypes() []string {
+	types := []string{}
+	for panelType := range panelConverters {
+		types = append(types, panelType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// graphOptions are the options of graph panels which are replaced by
+// options and fieldConfig of timeseries panels
+var graphOptions = []string{
+	"aliasColors", "bars", "dashLength", "dashes", "fill", "fillGradient", "legend",
+	"lines", "linewidth", "nullPointMode", "options", "percentage", "pointradius",
+	"points", "renderer", "seriesOverrides", "spaceLength", "stack", "steppedLine",
+	"thresholds", "timeRegions", "tooltip", "xaxis", "yaxes", "yaxis", "fieldConfig",
+}
+
+// singlestatOptions are the options of singlestat panels which are replaced
+// by options and fieldConfig of stat and gauge panels
+var singlestatOptions = []string{
+	"cacheTimeout", "colorBackground", "colorPostfix", "colorPrefix", "colorValue",
+	"colors", "decimals", "fieldConfig", "format", "gauge", "mappingType", "mappingTypes",
+	"nullPointMode", "nullText", "options", "postfix", "postfixFontSize", "prefix",
+	"prefixFontSize", "rangeMaps", "sparkline", "tableColumn", "thresholds",
+	"valueFontSize", "valueMaps", "valueName",
+}
+
+// tableOptions are the options of table-old panels which are replaced by
+// options, fieldConfig and transformations of table panels
+var tableOptions = []string{
+	"columns", "fieldConfig", "fontSize", "options", "pageSize", "scroll", "showHeader",
+	"sort", "styles", "transform",
+}
+
+// worldmapOptions are the options of worldmap panels which are replaced by
+// options of geomap panels
+var worldmapOptions = []string{
+	"circleMaxSize", "circleMinSize", "colors", "decimals", "esGeoPoint", "esLocationName",
+	"esMetric", "fieldConfig", "hideEmpty", "hideZero", "initialZoom", "jsonUrl",
+	"jsonpCallback", "locationData", "mapCenter", "mapCenterLatitude",
+	"mapCenterLongitude", "mouseWheelZoom", "options", "showLegend", "stickyLabels",
+	"tableQueryOptions", "thresholds", "unitPlural", "unitSingle", "unitSingular",
+	"valueName",
+}
+
+// reducers maps the valueName of singlestat and worldmap panels to the
+// reducers of newer panels
+var reducers = map[string]string{
+	"avg":     "mean",
+	"current": "lastNotNull",
+	"first":   "firstNotNull",
+	"max":     "max",
+	"min":     "min",
+	"total":   "sum",
+	"delta":   "delta",
+	"diff":    "diff",
+	"range":   "range",
+}
+
+// ConvertPanel converts a panel of a deprecated type like graph to its
+// successor. It returns the converted panel, the options which could not be
+// translated and false if the panel type has no successor.
+func ConvertPanel(panel map[string]interface{}) (map[string]interface{}, []string, bool) {
+	panelType, _ := panel["type"].(string)
+	converter, ok := panelConverters[panelType]
+	if !ok {
+		return panel, nil, false
+	}
+	converted, untranslated := converter(panel)
+	return converted, untranslated, true
+}
+
+// PanelConversion is a panel converted by ConvertDashboardPanels
+type PanelConversion struct {
+	PanelID      interface{} `json:"panelId"`
+	Title        string      `json:"title"`
+	From         string      `json:"from"`
+	To           string      `json:"to"`
+	Untranslated []string    `json:"untranslated"`
+}
+
+// ConvertDashboardPanels converts the panels of the given deprecated types,
+// or of all deprecated types if types is empty, including the panels of
+// collapsed rows.
+func ConvertDashboardPanels(dashboard map[string]interface{}, types []string) []PanelConversion {
+	selected := map[string]bool{}
+	for _, panelType := range types {
+		selected[panelType] = true
+	}
+
+	conversions := []PanelConversion{}
+	convertPanelMaps(dashboard, func(panel map[string]interface{}) map[string]interface{} {
+		panelType, _ := panel["type"].(string)
+		if len(selected) > 0 && !selected[panelType] {
+			return panel
+		}
+		converted, untranslated, ok := ConvertPanel(panel)
+		if !ok {
+			return panel
+		}
+		title, _ := panel["title"].(string)
+		conversions = append(conversions, PanelConversion{
+			PanelID:      panel["id"],
+			Title:        title,
+			From:         panelType,
+			To:           converted["type"].(string),
+			Untranslated: untranslated,
+		})
+		return converted
+	})
+	return conversions
+}
+
+// convertGraphPanel converts a graph panel to a timeseries panel
+func convertGraphPanel(panel map[string]interface{}) (map[string]interface{}, []string) {
+	p := typedPanel(panel)
+	converted := withoutKeys(panel, graphOptions)
+	converted["type"] = "timeseries"
+	untranslated := []string{}
+
+	custom := map[string]interface{}{
+		"drawStyle":         "line",
+		"lineInterpolation": "linear",
+		"lineWidth":         p.Linewidth,
+		"fillOpacity":       p.Fill * 10,
+		"showPoints":        "never",
+		"pointSize":         int(p.Pointradius * 2),
+		"spanNulls":         p.NullPointMode == "connected",
+		"stacking":          map[string]interface{}{"mode": "none", "group": "A"},
+	}
+	if _, ok := panel["linewidth"]; !ok {
+		custom["lineWidth"] = 1
+	}
+	switch {
+	case p.Bars:
+		custom["drawStyle"] = "bars"
+	case p.Points && !p.Lines:
+		custom["drawStyle"] = "points"
+	}
+	if p.Points {
+		custom["showPoints"] = "always"
+	}
+	if p.SteppedLine {
+		custom["lineInterpolation"] = "stepAfter"
+	}
+	if p.Stack {
+		mode := "normal"
+		if p.Percentage {
+			mode = "percent"
+		}
+		custom["stacking"] = map[string]interface{}{"mode": mode, "group": "A"}
+	}
+	if p.Dashes {
+		untranslated = append(untranslated, "dashes")
+	}
+
+	defaults := map[string]interface{}{"custom": custom}
+	if len(p.Yaxes) > 0 {
+		axis := p.Yaxes[0]
+		if axis.Format != "" && axis.Format != "short" {
+			defaults["unit"] = axis.Format
+		}
+		if value, ok := axisLimit(axis.Min); ok {
+			defaults["min"] = value
+		}
+		if value, ok := axisLimit(axis.Max); ok {
+			defaults["max"] = value
+		}
+		if label, ok := axis.Label.(string); ok && label != "" {
+			custom["axisLabel"] = label
+		}
+		if axis.LogBase > 1 {
+			custom["scaleDistribution"] = map[string]interface{}{"type": "log", "log": axis.LogBase}
+		}
+		if !axis.Show {
+			custom["axisPlacement"] = "hidden"
+		}
+	}
+	if len(p.Yaxes) > 1 && p.Yaxes[1].Show {
+		untranslated = append(untranslated, "yaxes[1]: right axis, use an override with axisPlacement right")
+	}
+	if p.Decimals != 0 {
+		defaults["decimals"] = p.Decimals
+	}
+	if steps, ok := graphThresholdSteps(p.Thresholds); ok {
+		defaults["thresholds"] = map[string]interface{}{"mode": "absolute", "steps": steps}
+		custom["thresholdsStyle"] = map[string]interface{}{"mode": "line"}
+	}
+
+	overrides := []interface{}{}
+	aliases := []string{}
+	for alias := range p.AliasColors {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+	for _, alias := range aliases {
+		overrides = append(overrides, map[string]interface{}{
+			"matcher": map[string]interface{}{"id": "byName", "options": alias},
+			"properties": []interface{}{map[string]interface{}{
+				"id":    "color",
+				"value": map[string]interface{}{"mode": "fixed", "fixedColor": p.AliasColors[alias]},
+			}},
+		})
+	}
+	if len(p.SeriesOverrides) > 0 {
+		untranslated = append(untranslated, fmt.Sprintf("seriesOverrides: %d series overrides", len(p.SeriesOverrides)))
+	}
+	if p.Xaxis.Mode != "" && p.Xaxis.Mode != "time" {
+		untranslated = append(untranslated, fmt.Sprintf("xaxis.mode: %s, use a barchart or histogram panel", p.Xaxis.Mode))
+	}
+	if regions, ok := panel["timeRegions"].([]interface{}); ok && len(regions) > 0 {
+		untranslated = append(untranslated, "timeRegions")
+	}
+	converted["fieldConfig"] = map[string]interface{}{"defaults": defaults, "overrides": overrides}
+
+	calcs := []string{}
+	for _, calc := range []struct {
+		enabled bool
+		name    string
+	}{{p.Legend.Min, "min"}, {p.Legend.Max, "max"}, {p.Legend.Avg, "mean"}, {p.Legend.Current, "lastNotNull"}, {p.Legend.Total, "sum"}} {
+		if calc.enabled && p.Legend.Values {
+			calcs = append(calcs, calc.name)
+		}
+	}
+	legend := map[string]interface{}{
+		"showLegend":  p.Legend.Show,
+		"displayMode": "list",
+		"placement":   "bottom",
+		"calcs":       calcs,
+	}
+	if p.Legend.AlignAsTable {
+		legend["displayMode"] = "table"
+	}
+	if p.Legend.RightSide {
+		legend["placement"] = "right"
+	}
+	tooltip := map[string]interface{}{"mode": "single", "sort": "none"}
+	if p.Tooltip.Shared {
+		tooltip["mode"] = "multi"
+	}
+	switch p.Tooltip.Sort {
+	case 1:
+		tooltip["sort"] = "asc"
+	case 2:
+		tooltip["sort"] = "desc"
+	}
+	converted["options"] = map[string]interface{}{"legend": legend, "tooltip": tooltip}
+
+	return converted, untranslated
+}
+
+// convertSinglestatPanel converts a singlestat panel to a stat panel or,
+// if the gauge is shown, to a gauge panel
+func convertSinglestatPanel(panel map[string]interface{}) (map[string]interface{}, []string) {
+	p := typedPanel(panel)
+	converted := withoutKeys(panel, singlestatOptions)
+	converted["type"] = "stat"
+	untranslated := []string{}
+
+	defaults := map[string]interface{}{}
+	if p.Format != "" && p.Format != "none" {
+		defaults["unit"] = p.Format
+	}
+	if _, ok := panel["decimals"].(float64); ok {
+		defaults["decimals"] = p.Decimals
+	}
+	if text, ok := p.NullText.(string); ok && text != "" {
+		defaults["noValue"] = text
+	}
+	if steps, ok := singlestatThresholdSteps(p.Thresholds, p.Colors); ok {
+		defaults["thresholds"] = map[string]interface{}{"mode": "absolute", "steps": steps}
+	}
+
+	mappings := []interface{}{}
+	if p.MappingType != 2 {
+		for _, valueMap := range p.ValueMaps {
+			if valueMap.Value == "null" {
+				mappings = append(mappings, map[string]interface{}{
+					"type":    "special",
+					"options": map[string]interface{}{"match": "null", "result": map[string]interface{}{"text": valueMap.Text}},
+				})
+				continue
+			}
+			mappings = append(mappings, map[string]interface{}{
+				"type":    "value",
+				"options": map[string]interface{}{valueMap.Value: map[string]interface{}{"text": valueMap.Text}},
+			})
+		}
+	} else {
+		for _, rangeMap := range p.RangeMaps {
+			from, okFrom := axisLimit(rangeMap.From)
+			to, okTo := axisLimit(rangeMap.To)
+			if !okFrom || !okTo {
+				untranslated = append(untranslated, fmt.Sprintf("rangeMaps: %s-%s", rangeMap.From, rangeMap.To))
+				continue
+			}
+			mappings = append(mappings, map[string]interface{}{
+				"type":    "range",
+				"options": map[string]interface{}{"from": from, "to": to, "result": map[string]interface{}{"text": rangeMap.Text}},
+			})
+		}
+	}
+	defaults["mappings"] = mappings
+
+	calc, ok := reducers[p.ValueName]
+	if !ok {
+		calc = "mean"
+	}
+	if p.ValueName == "name" {
+		untranslated = append(untranslated, "valueName: name")
+		calc = "lastNotNull"
+	}
+	reduceOptions := map[string]interface{}{"calcs": []string{calc}, "fields": "", "values": false}
+	if p.TableColumn != "" {
+		reduceOptions["fields"] = "/^" + regexp.QuoteMeta(p.TableColumn) + "$/"
+	}
+
+	colorMode := "none"
+	switch {
+	case p.ColorBackground:
+		colorMode = "background"
+	case p.ColorValue:
+		colorMode = "value"
+	}
+	graphMode := "none"
+	if p.Sparkline.Show {
+		graphMode = "area"
+	}
+	if p.Sparkline.Full {
+		untranslated = append(untranslated, "sparkline.full")
+	}
+	if p.Prefix != "" || p.Postfix != "" {
+		untranslated = append(untranslated, fmt.Sprintf("prefix/postfix: %q/%q, use a custom unit", p.Prefix, p.Postfix))
+	}
+
+	options := map[string]interface{}{
+		"reduceOptions": reduceOptions,
+		"colorMode":     colorMode,
+		"graphMode":     graphMode,
+		"justifyMode":   "auto",
+		"orientation":   "horizontal",
+		"textMode":      "auto",
+	}
+	if p.Gauge.Show {
+		converted["type"] = "gauge"
+		defaults["min"] = p.Gauge.MinValue
+		defaults["max"] = p.Gauge.MaxValue
+		options = map[string]interface{}{
+			"reduceOptions":        reduceOptions,
+			"showThresholdLabels":  p.Gauge.ThresholdLabels,
+			"showThresholdMarkers": p.Gauge.ThresholdMarkers,
+			"orientation":          "auto",
+		}
+	}
+	converted["options"] = options
+	converted["fieldConfig"] = map[string]interface{}{"defaults": defaults, "overrides": []interface{}{}}
+	return converted, untranslated
+}
+
+// convertTablePanel converts a table-old panel to a table panel. The style
+// matching all columns becomes the defaults, the other styles overrides.
+func convertTablePanel(panel map[string]interface{}) (map[string]interface{}, []string) {
+	converted := withoutKeys(panel, tableOptions)
+	converted["type"] = "table"
+	untranslated := []string{}
+
+	defaults := map[string]interface{}{"custom": map[string]interface{}{"align": "auto"}}
+	overrides := []interface{}{}
+	styles, _ := panel["styles"].([]interface{})
+	for _, item := range styles {
+		style, _ := item.(map[string]interface{})
+		pattern, _ := style["pattern"].(string)
+		properties, notes := tableStyleProperties(style)
+		for _, note := range notes {
+			untranslated = append(untranslated, fmt.Sprintf("styles %s: %s", pattern, note))
+		}
+
+		if pattern == "/.*/" || pattern == "" {
+			for _, property := range properties {
+				property := property.(map[string]interface{})
+				setFieldConfigProperty(defaults, property["id"].(string), property["value"])
+			}
+			continue
+		}
+		if len(properties) == 0 {
+			continue
+		}
+		matcher := map[string]interface{}{"id": "byName", "options": pattern}
+		if len(pattern) > 1 && strings.HasPrefix(pattern, "/") && strings.HasSuffix(pattern, "/") {
+			matcher = map[string]interface{}{"id": "byRegexp", "options": pattern}
+		}
+		overrides = append(overrides, map[string]interface{}{"matcher": matcher, "properties": properties})
+	}
+	converted["fieldConfig"] = map[string]interface{}{"defaults": defaults, "overrides": overrides}
+
+	options := map[string]interface{}{"showHeader": true}
+	if showHeader, ok := panel["showHeader"].(bool); ok {
+		options["showHeader"] = showHeader
+	}
+	if sortBy, ok := panel["sort"].(map[string]interface{}); ok && sortBy["col"] != nil {
+		untranslated = append(untranslated, fmt.Sprintf("sort: column %v, choose the column by name", sortBy["col"]))
+	}
+	converted["options"] = options
+
+	transformations, _ := panel["transformations"].([]interface{})
+	switch transform, _ := panel["transform"].(string); transform {
+	case "", "timeseries_to_rows", "table":
+	case "timeseries_to_columns":
+		transformations = append(transformations, map[string]interface{}{"id": "seriesToColumns", "options": map[string]interface{}{}})
+	case "timeseries_aggregations":
+		calcs := []string{}
+		columns, _ := panel["columns"].([]interface{})
+		for _, item := range columns {
+			column, _ := item.(map[string]interface{})
+			value, _ := column["value"].(string)
+			if calc, ok := reducers[value]; ok {
+				calcs = append(calcs, calc)
+			}
+		}
+		transformations = append(transformations, map[string]interface{}{"id": "reduce", "options": map[string]interface{}{"reducers": calcs}})
+	default:
+		untranslated = append(untranslated, "transform: "+transform)
+	}
+	if len(transformations) > 0 {
+		converted["transformations"] = transformations
+	}
+	return converted, untranslated
+}
+
+// tableStyleProperties converts a column style of a table-old panel to
+// field config properties
+func tableStyleProperties(style map[string]interface{}) ([]interface{}, []string) {
+	properties := []interface{}{}
+	notes := []string{}
+	add := func(id string, value interface{}) {
+		properties = append(properties, map[string]interface{}{"id": id, "value": value})
+	}
+
+	if alias, _ := style["alias"].(string); alias != "" {
+		add("displayName", alias)
+	}
+	switch styleType, _ := style["type"].(string); styleType {
+	case "hidden":
+		add("custom.hidden", true)
+	case "date":
+		if format, _ := style["dateFormat"].(string); format != "" && format != "YYYY-MM-DD HH:mm:ss" {
+			notes = append(notes, "dateFormat "+format)
+		}
+	case "number":
+		if unit, _ := style["unit"].(string); unit != "" && unit != "short" {
+			add("unit", unit)
+		}
+		if decimals, ok := style["decimals"].(float64); ok {
+			add("decimals", decimals)
+		}
+	case "string":
+		if mappings, _ := style["valueMaps"].([]interface{}); len(mappings) > 0 {
+			notes = append(notes, "valueMaps")
+		}
+	}
+
+	colorMode, _ := style["colorMode"].(string)
+	if colorMode != "" {
+		var thresholds []string
+		items, _ := style["thresholds"].([]interface{})
+		for _, item := range items {
+			thresholds = append(thresholds, fmt.Sprint(item))
+		}
+		var colors []string
+		colorItems, _ := style["colors"].([]interface{})
+		for _, item := range colorItems {
+			colors = append(colors, fmt.Sprint(item))
+		}
+		raw, _ := json.Marshal(strings.Join(thresholds, ","))
+		if steps, ok := singlestatThresholdSteps(raw, colors); ok {
+			add("thresholds", map[string]interface{}{"mode": "absolute", "steps": steps})
+		}
+		cellType := "color-background"
+		if colorMode == "value" {
+			cellType = "color-text"
+		}
+		if colorMode == "row" {
+			notes = append(notes, "colorMode row, the cell is colored")
+		}
+		add("custom.cellOptions", map[string]interface{}{"type": cellType})
+	}
+	if link, _ := style["link"].(bool); link {
+		notes = append(notes, "link")
+	}
+	return properties, notes
+}
+
+// setFieldConfigProperty sets a property like custom.hidden in defaults
+func setFieldConfigProperty(defaults map[string]interface{}, id string, value interface{}) {
+	if strings.HasPrefix(id, "custom.") {
+		custom, _ := defaults["custom"].(map[string]interface{})
+		if custom == nil {
+			custom = map[string]interface{}{}
+			defaults["custom"] = custom
+		}
+		custom[strings.TrimPrefix(id, "custom.")] = value
+		return
+	}
+	defaults[id] = value
+}
+
+// convertWorldmapPanel converts a worldmap panel to a geomap panel, only the
+// view is translated, the location data has to be configured again
+func convertWorldmapPanel(panel map[string]interface{}) (map[string]interface{}, []string) {
+	converted := withoutKeys(panel, worldmapOptions)
+	converted["type"] = "geomap"
+	untranslated := []string{}
+
+	view := map[string]interface{}{"id": "zero", "lat": 0, "lon": 0, "zoom": 1}
+	switch center, _ := panel["mapCenter"].(string); center {
+	case "custom":
+		lat, _ := axisLimit(panel["mapCenterLatitude"])
+		lon, _ := axisLimit(panel["mapCenterLongitude"])
+		view = map[string]interface{}{"id": "coords", "lat": lat, "lon": lon}
+	case "Europe":
+		view = map[string]interface{}{"id": "europe"}
+	case "North America":
+		view = map[string]interface{}{"id": "north-america"}
+	case "South America":
+		view = map[string]interface{}{"id": "south-america"}
+	case "Asia":
+		view = map[string]interface{}{"id": "asia"}
+	case "West Asia":
+		view = map[string]interface{}{"id": "middle-east"}
+	case "", "(0°, 0°)":
+	default:
+		untranslated = append(untranslated, "mapCenter: "+center)
+	}
+	if zoom, ok := axisLimit(panel["initialZoom"]); ok {
+		view["zoom"] = zoom
+	}
+
+	if locationData, _ := panel["locationData"].(string); locationData != "" {
+		untranslated = append(untranslated, "locationData: "+locationData+", configure the location of the markers layer")
+	}
+	defaults := map[string]interface{}{}
+	if decimals, ok := panel["decimals"].(float64); ok {
+		defaults["decimals"] = decimals
+	}
+	if unit, _ := panel["unitPlural"].(string); unit != "" {
+		untranslated = append(untranslated, "unitPlural: "+unit)
+	}
+
+	converted["fieldConfig"] = map[string]interface{}{"defaults": defaults, "overrides": []interface{}{}}
+	converted["options"] = map[string]interface{}{
+		"view": view,
+		"controls": map[string]interface{}{
+			"showZoom":        true,
+			"mouseWheelZoom":  panel["mouseWheelZoom"] == true,
+			"showAttribution": true,
+		},
+		"basemap": map[string]interface{}{"type": "default", "name": "Basemap"},
+		"layers": []interface{}{map[string]interface{}{
+			"type": "markers",
+			"name": "Markers",
+			"config": map[string]interface{}{
+				"showLegend": panel["showLegend"] != false,
+				"style": map[string]interface{}{
+					"size": map[string]interface{}{"min": panel["circleMinSize"], "max": panel["circleMaxSize"], "field": ""},
+				},
+			},
+			"location": map[string]interface{}{"mode": "auto"},
+		}},
+	}
+	return converted, untranslated
+}
+
+// singlestatThresholdSteps converts the thresholds of a singlestat panel or
+// a table-old style, a string like "50,80", with its colors to steps
+func singlestatThresholdSteps(raw json.RawMessage, colors []string) ([]interface{}, bool) {
+	var thresholds string
+	if len(raw) == 0 || json.Unmarshal(raw, &thresholds) != nil || strings.TrimSpace(thresholds) == "" {
+		return nil, false
+	}
+	color := func(i int) string {
+		if i < len(colors) {
+			return colors[i]
+		}
+		return "red"
+	}
+
+	steps := []interface{}{map[string]interface{}{"color": color(0), "value": nil}}
+	for i, value := range strings.Split(thresholds, ",") {
+		number, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return nil, false
+		}
+		steps = append(steps, map[string]interface{}{"color": color(i + 1), "value": number})
+	}
+	return steps, true
+}
+
+// graphThresholdSteps converts the thresholds of a graph panel, a list of
+// {"value":80,"op":"gt","colorMode":"critical"}, to threshold steps
+func graphThresholdSteps(raw json.RawMessage) ([]interface{}, bool) {
+	var thresholds []struct {
+		Value     *float64 `json:"value"`
+		Op        string   `json:"op"`
+		ColorMode string   `json:"colorMode"`
+		LineColor string   `json:"lineColor"`
+	}
+	if len(raw) == 0 || json.Unmarshal(raw, &thresholds) != nil || len(thresholds) == 0 {
+		return nil, false
+	}
+	colors := map[string]string{"critical": "red", "warning": "orange", "ok": "green"}
+
+	steps := []interface{}{map[string]interface{}{"color": "green", "value": nil}}
+	for _, threshold := range thresholds {
+		if threshold.Value == nil {
+			continue
+		}
+		color, ok := colors[threshold.ColorMode]
+		if !ok {
+			color = threshold.LineColor
+		}
+		if color == "" {
+			color = "red"
+		}
+		steps = append(steps, map[string]interface{}{"color": color, "value": *threshold.Value})
+	}
+	return steps, len(steps) > 1
+}
+
+// axisLimit returns the min or max of an axis, which is a string or number
+func axisLimit(value interface{}) (float64, bool) {
+	switch limit := value.(type) {
+	case float64:
+		return limit, true
+	case string:
+		var number float64
+		_, err := fmt.Sscan(limit, &number)
+		return number, err == nil
+	}
+	return 0, false
+}
+
+// withoutKeys returns a copy of object without the keys
+func withoutKeys(object map[string]interface{}, keys []string) map[string]interface{} {
+	remove := map[string]bool{}
+	for _, key := range keys {
+		remove[key] = true
+	}
+	copied := map[string]interface{}{}
+	for key, value := range object {
+		if !remove[key] {
+			copied[key] = value
+		}
+	}
+	return copied
+}
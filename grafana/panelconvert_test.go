@@ -0,0 +1,87 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package grafana_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/lstuker/grafana-tool/grafana"
+)
+
+func TestConvertPanel(t *testing.T) {
+	tables := []struct {
+		panel        string
+		expect       string
+		untranslated int
+	}{
+		{`{"id":1,"type":"singlestat","title":"Uptime","format":"s","decimals":1,"valueName":"current",
+			"colorBackground":true,"colors":["green","orange","red"],"thresholds":"50,80",
+			"sparkline":{"show":true},"prefix":"up ","nullText":"n/a",
+			"valueMaps":[{"op":"=","value":"0","text":"down"},{"op":"=","value":"null","text":"none"}]}`,
+			`{"id":1,"type":"stat","title":"Uptime",
+			"fieldConfig":{"defaults":{"unit":"s","decimals":1,"noValue":"n/a",
+				"thresholds":{"mode":"absolute","steps":[{"color":"green","value":null},{"color":"orange","value":50},{"color":"red","value":80}]},
+				"mappings":[{"type":"value","options":{"0":{"text":"down"}}},
+					{"type":"special","options":{"match":"null","result":{"text":"none"}}}]},"overrides":[]},
+			"options":{"reduceOptions":{"calcs":["lastNotNull"],"fields":"","values":false},"colorMode":"background",
+				"graphMode":"area","justifyMode":"auto","orientation":"horizontal","textMode":"auto"}}`, 1},
+		{`{"id":2,"type":"singlestat","valueName":"max","gauge":{"show":true,"minValue":0,"maxValue":100,"thresholdMarkers":true}}`,
+			`{"id":2,"type":"gauge",
+			"fieldConfig":{"defaults":{"min":0,"max":100,"mappings":[]},"overrides":[]},
+			"options":{"reduceOptions":{"calcs":["max"],"fields":"","values":false},
+				"showThresholdLabels":false,"showThresholdMarkers":true,"orientation":"auto"}}`, 0},
+		{`{"id":3,"type":"table-old","transform":"timeseries_aggregations","columns":[{"text":"Avg","value":"avg"}],
+			"styles":[{"pattern":"Time","type":"hidden"},{"pattern":"/.*/","type":"number","unit":"bytes","decimals":2},
+				{"pattern":"Status","type":"string","link":true}]}`,
+			`{"id":3,"type":"table",
+			"fieldConfig":{"defaults":{"unit":"bytes","decimals":2,"custom":{"align":"auto"}},"overrides":[
+				{"matcher":{"id":"byName","options":"Time"},"properties":[{"id":"custom.hidden","value":true}]}]},
+			"options":{"showHeader":true},
+			"transformations":[{"id":"reduce","options":{"reducers":["mean"]}}]}`, 1},
+		{`{"id":4,"type":"grafana-worldmap-panel","mapCenter":"custom","mapCenterLatitude":"47","mapCenterLongitude":8,
+			"initialZoom":"5","locationData":"countries","circleMinSize":2,"circleMaxSize":30}`,
+			`{"id":4,"type":"geomap","fieldConfig":{"defaults":{},"overrides":[]},
+			"options":{"view":{"id":"coords","lat":47,"lon":8,"zoom":5},
+				"controls":{"showZoom":true,"mouseWheelZoom":false,"showAttribution":true},
+				"basemap":{"type":"default","name":"Basemap"},
+				"layers":[{"type":"markers","name":"Markers","location":{"mode":"auto"},
+					"config":{"showLegend":true,"style":{"size":{"min":2,"max":30,"field":""}}}}]}}`, 1},
+	}
+
+	for _, table := range tables {
+		var panel, expect, got map[string]interface{}
+		json.Unmarshal([]byte(table.panel), &panel)
+		json.Unmarshal([]byte(table.expect), &expect)
+		converted, untranslated, ok := grafana.ConvertPanel(panel)
+		if !ok {
+			t.Errorf("Is was  incorrect, got: %v, want: %v.", ok, true)
+		}
+		data, _ := json.Marshal(converted)
+		json.Unmarshal(data, &got)
+		for _, change := range grafana.DiffJSON(expect, got) {
+			t.Errorf("Is was  incorrect: %s", change)
+		}
+		if len(untranslated) != table.untranslated {
+			t.Errorf("Is was  incorrect, got: %v, want: %v.", untranslated, table.untranslated)
+		}
+	}
+}
+
+func TestConvertPanelUnknownType(t *testing.T) {
+	panel := map[string]interface{}{"type": "timeseries"}
+	if _, _, ok := grafana.ConvertPanel(panel); ok {
+		t.Errorf("Is was  incorrect, got: %v, want: %v.", ok, false)
+	}
+}
@@ -0,0 +1,219 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grafana
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// Playlist item types
+const (
+	PlaylistItemDashboardByID  = "dashboard_by_id"
+	PlaylistItemDashboardByUID = "dashboard_by_uid"
+	PlaylistItemDashboardByTag = "dashboard_by_tag"
+)
+
+// PlaylistListJSON is a list of playlists from the Grafana API
+// More info: https://grafana.com/docs/http_api/playlist/
+type PlaylistListJSON []PlaylistJSON
+
+// PlaylistJSON is a playlist from the Grafana API
+// More info: https://grafana.com/docs/http_api/playlist/
+type PlaylistJSON struct {
+	ID       int                `json:"id,omitempty"`
+	UID      string             `json:"uid"`
+	Name     string             `json:"name"`
+	Interval string             `json:"interval"`
+	Items    []PlaylistItemJSON `json:"items"`
+}
+
+// PlaylistItemJSON is a dashboard or a tag of a playlist
+// More info: https://grafana.com/docs/http_api/playlist/
+type PlaylistItemJSON struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+	Order int    `json:"order,omitempty"`
+	Title string `json:"title,omitempty"`
+}
+
+// GetPlaylists returns all playlists without their items.
+// It reflects GET /api/playlists API call.
+// More info: https://grafana.com/docs/http_api/playlist/
+func (r *Client) GetPlaylists() (PlaylistListJSON, error) {
+	var (
+		records PlaylistListJSON
+		raw     []byte
+		code    int
+		err     error
+	)
+
+	raw, code, err = r.getRequest("/api/playlists", nil)
+
+	if err != nil && code != 200 {
+		return records, err
+	}
+	if code != 200 {
+		return nil, fmt.Errorf("HTTP error %d: returns %s", code, raw)
+	}
+
+	err = json.Unmarshal(raw, &records)
+	return records, err
+}
+
+// ErrPlaylistNotFound is returned when Grafana has no playlist with the UID
+var ErrPlaylistNotFound = errors.New("playlist not found")
+
+// GetPlaylistByUID returns the playlist with the given UID and its items,
+// ErrPlaylistNotFound if it does not exist.
+// It reflects GET /api/playlists/:uid API call.
+// More info: https://grafana.com/docs/http_api/playlist/
+func (r *Client) GetPlaylistByUID(UID string) (PlaylistJSON, error) {
+	var (
+		record PlaylistJSON
+		raw    []byte
+		code   int
+		err    error
+	)
+
+	raw, code, err = r.getRequest(fmt.Sprintf("/api/playlists/%s", UID), nil)
+
+	if err != nil && code != 200 {
+		return record, err
+	}
+	if code == 404 {
+		return record, ErrPlaylistNotFound
+	}
+	if code != 200 {
+		return record, fmt.Errorf("HTTP error %d: returns %s", code, raw)
+	}
+
+	err = json.Unmarshal(raw, &record)
+	return record, err
+}
+
+// CreatePlaylist creates a playlist.
+// It reflects POST /api/playlists API call.
+// More info: https://grafana.com/docs/http_api/playlist/
+func (r *Client) CreatePlaylist(playlist PlaylistJSON) (PlaylistJSON, error) {
+	var record PlaylistJSON
+
+	playlist.ID = 0
+	body, err := json.Marshal(playlist)
+	if err != nil {
+		return record, err
+	}
+
+	raw, code, err := r.postRequest("/api/playlists", nil, body)
+
+	if err != nil && code != 200 {
+		return record, err
+	}
+	if code != 200 {
+		return record, fmt.Errorf("HTTP error %d: returns %s", code, raw)
+	}
+
+	err = json.Unmarshal(raw, &record)
+	return record, err
+}
+
+// UpdatePlaylist replaces name, interval and items of a playlist.
+// It reflects PUT /api/playlists/:uid API call.
+// More info: https://grafana.com/docs/http_api/playlist/
+func (r *Client) UpdatePlaylist(playlist PlaylistJSON) (PlaylistJSON, error) {
+	var record PlaylistJSON
+
+	playlist.ID = 0
+	body, err := json.Marshal(playlist)
+	if err != nil {
+		return record, err
+	}
+
+	raw, code, err := r.putRequest(fmt.Sprintf("/api/playlists/%s", playlist.UID), nil, body)
+
+	if err != nil && code != 200 {
+		return record, err
+	}
+	if code != 200 {
+		return record, fmt.Errorf("HTTP error %d: returns %s", code, raw)
+	}
+
+	err = json.Unmarshal(raw, &record)
+	return record, err
+}
+
+// DeletePlaylist deletes the playlist with the given UID.
+// It reflects DELETE /api/playlists/:uid API call.
+// More info: https://grafana.com/docs/http_api/playlist/
+func (r *Client) DeletePlaylist(UID string) error {
+	raw, code, err := r.deleteRequest(fmt.Sprintf("/api/playlists/%s", UID))
+
+	if err != nil && code != 200 {
+		return err
+	}
+	if code != 200 {
+		return fmt.Errorf("HTTP error %d: returns %s", code, raw)
+	}
+	return nil
+}
+
+// WithDashboardUIDs returns the playlist with its dashboard_by_id items
+// replaced by dashboard_by_uid items, which can be used on every Grafana.
+// Items of unknown dashboards are dropped and returned as missing.
+func (p PlaylistJSON) WithDashboardUIDs(uids map[int]string) (PlaylistJSON, []PlaylistItemJSON) {
+	items := []PlaylistItemJSON{}
+	missing := []PlaylistItemJSON{}
+
+	for _, item := range p.Items {
+		if item.Type == PlaylistItemDashboardByID {
+			id, _ := strconv.Atoi(item.Value)
+			uid, ok := uids[id]
+			if !ok {
+				missing = append(missing, item)
+				continue
+			}
+			item.Type = PlaylistItemDashboardByUID
+			item.Value = uid
+		}
+		items = append(items, item)
+	}
+	p.Items = items
+	return p, missing
+}
+
+// WithDashboardIDs returns the playlist with its dashboard_by_uid items
+// replaced by dashboard_by_id items of the given Grafana.
+// Items of unknown dashboards are dropped and returned as missing.
+func (p PlaylistJSON) WithDashboardIDs(ids map[string]int) (PlaylistJSON, []PlaylistItemJSON) {
+	items := []PlaylistItemJSON{}
+	missing := []PlaylistItemJSON{}
+
+	for _, item := range p.Items {
+		if item.Type == PlaylistItemDashboardByUID {
+			id, ok := ids[item.Value]
+			if !ok {
+				missing = append(missing, item)
+				continue
+			}
+			item.Type = PlaylistItemDashboardByID
+			item.Value = strconv.Itoa(id)
+		}
+		items = append(items, item)
+	}
+	p.Items = items
+	return p, missing
+}
@@ -0,0 +1,46 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package grafana_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/lstuker/grafana-tool/grafana"
+)
+
+func TestPlaylistDashboardUIDs(t *testing.T) {
+	var playlist grafana.PlaylistJSON
+	playlist_str := []byte(`{"uid":"noc","name":"NOC","interval":"5m","items":[
+		{"type":"dashboard_by_id","value":"3","order":1},
+		{"type":"dashboard_by_tag","value":"noc","order":2},
+		{"type":"dashboard_by_id","value":"9","order":3}]}`)
+	json.Unmarshal(playlist_str, &playlist)
+
+	exported, missing := playlist.WithDashboardUIDs(map[int]string{3: "linux-cpu"})
+	if len(exported.Items) != 2 || exported.Items[0].Type != "dashboard_by_uid" || exported.Items[0].Value != "linux-cpu" {
+		t.Errorf("Is was  incorrect, got: %v.", exported.Items)
+	}
+	if len(missing) != 1 || missing[0].Value != "9" {
+		t.Errorf("Is was  incorrect, got: %v, want the item of dashboard 9.", missing)
+	}
+
+	imported, missing := exported.WithDashboardIDs(map[string]int{"linux-cpu": 42})
+	if len(missing) != 0 || imported.Items[0].Type != "dashboard_by_id" || imported.Items[0].Value != "42" {
+		t.Errorf("Is was  incorrect, got: %v.", imported.Items)
+	}
+	if imported.Items[1].Type != "dashboard_by_tag" || imported.Items[1].Value != "noc" {
+		t.Errorf("Is was  incorrect, got: %v, want the tag item unchanged.", imported.Items[1])
+	}
+}
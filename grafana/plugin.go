@@ -0,0 +1,177 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grafana
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// PluginListJSON is a list of plugins from the Grafana API
+// More info: https://grafana.com/docs/http_api/plugin/
+type PluginListJSON []PluginJSON
+
+// PluginJSON is an installed plugin from the Grafana API
+// More info: https://grafana.com/docs/http_api/plugin/
+type PluginJSON struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Enabled bool   `json:"enabled"`
+	Pinned  bool   `json:"pinned"`
+	Info    struct {
+		Version string `json:"version"`
+		Author  struct {
+			Name string `json:"name"`
+		} `json:"author"`
+	} `json:"info"`
+}
+
+// PluginRequirement is a plugin a dashboard needs, Type is panel or
+// datasource
+type PluginRequirement struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+// GetPlugins returns the installed plugins including the core plugins,
+// pluginType filters for panel, datasource or app if not empty.
+// It reflects GET /api/plugins API call.
+// More info: https://grafana.com/docs/http_api/plugin/
+func (r *Client) GetPlugins(pluginType string) (PluginListJSON, error) {
+	var (
+		records PluginListJSON
+		raw     []byte
+		code    int
+		err     error
+	)
+
+	q := url.Values{}
+	if pluginType != "" {
+		q.Set("type", pluginType)
+	}
+
+	raw, code, err = r.getRequest("/api/plugins", q)
+
+	if err != nil && code != 200 {
+		return records, err
+	}
+	if code != 200 {
+		return nil, fmt.Errorf("HTTP error %d: returns %s", code, raw)
+	}
+
+	err = json.Unmarshal(raw, &records)
+	return records, err
+}
+
+// Installed returns true if a plugin with the given ID is in the list
+func (plugins PluginListJSON) Installed(ID string) bool {
+	for _, plugin := range plugins {
+		if plugin.ID == ID {
+			return true
+		}
+	}
+	return false
+}
+
+// RequiredPlugins returns the panel and datasource plugins the dashboard
+// needs, including panels in collapsed rows. Datasources referenced by name
+// or UID are resolved with datasources to their plugin type, references
+// which can not be resolved, like template variables, are returned in
+// unresolved. Built-in datasources like "-- Grafana --" are ignored.
+func (d DashboardJSON) RequiredPlugins(datasources DatasourceListJSON) (required []PluginRequirement, unresolved []string) {
+	seen := map[PluginRequirement]bool{}
+	add := func(requirement PluginRequirement) {
+		if requirement.ID != "" && !seen[requirement] {
+			seen[requirement] = true
+			required = append(required, requirement)
+		}
+	}
+	unresolvedSeen := map[string]bool{}
+	addDatasource := func(ref interface{}) {
+		pluginType, name := datasourceType(ref, datasources)
+		if pluginType != "" {
+			add(PluginRequirement{Type: "datasource", ID: pluginType})
+		} else if name != "" && !unresolvedSeen[name] {
+			unresolvedSeen[name] = true
+			unresolved = append(unresolved, name)
+		}
+	}
+
+	for _, panel := range d.AllPanels() {
+		if panel.Type != "row" {
+			add(PluginRequirement{Type: "panel", ID: panel.Type})
+		}
+		addDatasource(panel.Datasource)
+		for _, target := range panel.Targets {
+			addDatasource(target.Datasource)
+		}
+	}
+	for _, variable := range d.Templating.List {
+		addDatasource(variable.Datasource)
+	}
+	for _, annotation := range d.Annotations.List {
+		addDatasource(annotation.Datasource)
+	}
+
+	sort.Slice(required, func(i, j int) bool {
+		if required[i].Type != required[j].Type {
+			return required[i].Type < required[j].Type
+		}
+		return required[i].ID < required[j].ID
+	})
+	sort.Strings(unresolved)
+	return required, unresolved
+}
+
+// datasourceType returns the plugin type of a datasource reference, which
+// is a name in older dashboards and {"type":..,"uid":..} in newer ones.
+// If the type is unknown the reference is returned as name.
+func datasourceType(ref interface{}, datasources DatasourceListJSON) (string, string) {
+	switch value := ref.(type) {
+	case string:
+		if value == "" || builtinDatasource(value) {
+			return "", ""
+		}
+		for _, datasource := range datasources {
+			if datasource.Name == value || datasource.UID == value {
+				return datasource.Type, value
+			}
+		}
+		return "", value
+	case map[string]interface{}:
+		pluginType, _ := value["type"].(string)
+		uid, _ := value["uid"].(string)
+		if builtinDatasource(pluginType) || builtinDatasource(uid) {
+			return "", ""
+		}
+		if pluginType != "" {
+			return pluginType, uid
+		}
+		if uid != "" {
+			return datasourceType(uid, datasources)
+		}
+	}
+	return "", ""
+}
+
+// builtinDatasource returns true for datasources which are part of Grafana
+// and not a plugin, like "-- Grafana --", "-- Mixed --" or "-- Dashboard --"
+func builtinDatasource(name string) bool {
+	return strings.HasPrefix(name, "-- ") || name == "grafana" || name == "datasource"
+}
@@ -0,0 +1,71 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package grafana_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/lstuker/grafana-tool/grafana"
+)
+
+func TestRequiredPlugins(t *testing.T) {
+	var dashboard grafana.DashboardJSON
+	dashboard_json := []byte(`{
+		"annotations":{"list":[{"builtIn":1,"datasource":"-- Grafana --"}]},
+		"templating":{"list":[{"name":"host","datasource":"influx","query":{"query":"SHOW TAG VALUES"}}]},
+		"panels":[
+			{"id":1,"type":"graph","datasource":"influx"},
+			{"id":2,"type":"stat","datasource":{"type":"prometheus","uid":"prom"}},
+			{"id":3,"type":"row","collapsed":true,"panels":[
+				{"id":4,"type":"grafana-piechart-panel","datasource":"$ds"},
+				{"id":5,"type":"timeseries","datasource":{"type":"datasource","uid":"-- Mixed --"},
+					"targets":[{"refId":"A","datasource":{"uid":"loki1"}}]}]}]}`)
+	err := json.Unmarshal(dashboard_json, &dashboard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	datasources := grafana.DatasourceListJSON{
+		{Name: "influx", UID: "influx1", Type: "influxdb"},
+		{Name: "loki", UID: "loki1", Type: "loki"},
+	}
+
+	required, unresolved := dashboard.RequiredPlugins(datasources)
+	expect := []grafana.PluginRequirement{
+		{Type: "datasource", ID: "influxdb"},
+		{Type: "datasource", ID: "loki"},
+		{Type: "datasource", ID: "prometheus"},
+		{Type: "panel", ID: "grafana-piechart-panel"},
+		{Type: "panel", ID: "graph"},
+		{Type: "panel", ID: "stat"},
+		{Type: "panel", ID: "timeseries"},
+	}
+	if !reflect.DeepEqual(required, expect) {
+		t.Errorf("Is was  incorrect, got: %v, want: %v.", required, expect)
+	}
+	if len(unresolved) != 1 || unresolved[0] != "$ds" {
+		t.Errorf("Is was  incorrect, got: %v, want: %v.", unresolved, []string{"$ds"})
+	}
+}
+
+func TestPluginInstalled(t *testing.T) {
+	plugins := grafana.PluginListJSON{{ID: "graph"}, {ID: "prometheus"}}
+	if !plugins.Installed("prometheus") {
+		t.Errorf("Expected prometheus to be installed")
+	}
+	if plugins.Installed("grafana-worldmap-panel") {
+		t.Errorf("Expected grafana-worldmap-panel not to be installed")
+	}
+}
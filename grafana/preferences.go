@@ -0,0 +1,132 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grafana
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// PreferencesJSON are the preferences of an organisation, team or user
+// More info: https://grafana.com/docs/http_api/preferences/
+type PreferencesJSON struct {
+	Theme            string `json:"theme"`
+	HomeDashboardID  int    `json:"homeDashboardId"`
+	HomeDashboardUID string `json:"homeDashboardUID,omitempty"`
+	Timezone         string `json:"timezone"`
+	WeekStart        string `json:"weekStart"`
+}
+
+// GetOrgPreferences returns the preferences of the current organisation.
+// It reflects GET /api/org/preferences API call.
+// More info: https://grafana.com/docs/http_api/preferences/
+func (r *Client) GetOrgPreferences() (PreferencesJSON, error) {
+	return r.getPreferences("/api/org/preferences")
+}
+
+// UpdateOrgPreferences replaces the preferences of the current organisation.
+// It reflects PUT /api/org/preferences API call.
+// More info: https://grafana.com/docs/http_api/preferences/
+func (r *Client) UpdateOrgPreferences(preferences PreferencesJSON) error {
+	return r.updatePreferences("/api/org/preferences", preferences)
+}
+
+// GetTeamPreferences returns the preferences of a team.
+// It reflects GET /api/teams/:id/preferences API call.
+// More info: https://grafana.com/docs/http_api/team/
+func (r *Client) GetTeamPreferences(teamID int) (PreferencesJSON, error) {
+	return r.getPreferences(fmt.Sprintf("/api/teams/%d/preferences", teamID))
+}
+
+// UpdateTeamPreferences replaces the preferences of a team.
+// It reflects PUT /api/teams/:id/preferences API call.
+// More info: https://grafana.com/docs/http_api/team/
+func (r *Client) UpdateTeamPreferences(teamID int, preferences PreferencesJSON) error {
+	return r.updatePreferences(fmt.Sprintf("/api/teams/%d/preferences", teamID), preferences)
+}
+
+// GetUserPreferences returns the preferences of the authenticated user.
+// It reflects GET /api/user/preferences API call.
+// More info: https://grafana.com/docs/http_api/preferences/
+func (r *Client) GetUserPreferences() (PreferencesJSON, error) {
+	return r.getPreferences("/api/user/preferences")
+}
+
+// UpdateUserPreferences replaces the preferences of the authenticated user.
+// It reflects PUT /api/user/preferences API call.
+// More info: https://grafana.com/docs/http_api/preferences/
+func (r *Client) UpdateUserPreferences(preferences PreferencesJSON) error {
+	return r.updatePreferences("/api/user/preferences", preferences)
+}
+
+// WithHomeDashboardUID returns the preferences with the home dashboard
+// referenced by UID only, so they can be used on another Grafana
+func (p PreferencesJSON) WithHomeDashboardUID(uids map[int]string) PreferencesJSON {
+	if p.HomeDashboardUID == "" && p.HomeDashboardID != 0 {
+		p.HomeDashboardUID = uids[p.HomeDashboardID]
+	}
+	p.HomeDashboardID = 0
+	return p
+}
+
+// WithHomeDashboardID returns the preferences with the home dashboard ID of
+// the given Grafana. It returns false if the home dashboard does not exist.
+func (p PreferencesJSON) WithHomeDashboardID(ids map[string]int) (PreferencesJSON, bool) {
+	if p.HomeDashboardUID == "" {
+		p.HomeDashboardID = 0
+		return p, true
+	}
+	id, ok := ids[p.HomeDashboardUID]
+	p.HomeDashboardID = id
+	return p, ok
+}
+
+func (r *Client) getPreferences(path string) (PreferencesJSON, error) {
+	var (
+		record PreferencesJSON
+		raw    []byte
+		code   int
+		err    error
+	)
+
+	raw, code, err = r.getRequest(path, nil)
+
+	if err != nil && code != 200 {
+		return record, err
+	}
+	if code != 200 {
+		return record, fmt.Errorf("HTTP error %d: returns %s", code, raw)
+	}
+
+	err = json.Unmarshal(raw, &record)
+	return record, err
+}
+
+func (r *Client) updatePreferences(path string, preferences PreferencesJSON) error {
+	body, err := json.Marshal(preferences)
+	if err != nil {
+		return err
+	}
+
+	raw, code, err := r.putRequest(path, nil, body)
+
+	if err != nil && code != 200 {
+		return err
+	}
+	if code != 200 {
+		return fmt.Errorf("HTTP error %d: returns %s", code, raw)
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package grafana_test
+
+import (
+	"testing"
+
+	"github.com/lstuker/grafana-tool/grafana"
+)
+
+func TestPreferencesHomeDashboard(t *testing.T) {
+	preferences := grafana.PreferencesJSON{Theme: "dark", HomeDashboardID: 12, Timezone: "utc"}
+
+	exported := preferences.WithHomeDashboardUID(map[int]string{12: "home"})
+	if exported.HomeDashboardID != 0 || exported.HomeDashboardUID != "home" {
+		t.Errorf("Is was  incorrect, got: %d %s, want: 0 home.", exported.HomeDashboardID, exported.HomeDashboardUID)
+	}
+
+	imported, ok := exported.WithHomeDashboardID(map[string]int{"home": 42})
+	if !ok || imported.HomeDashboardID != 42 {
+		t.Errorf("Is was  incorrect, got: %d, want: %d.", imported.HomeDashboardID, 42)
+	}
+
+	_, ok = exported.WithHomeDashboardID(map[string]int{})
+	if ok {
+		t.Errorf("Expected a missing home dashboard")
+	}
+}
@@ -0,0 +1,196 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grafana
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// PublicDashboardListJSON is a list of public dashboards from the Grafana API
+// More info: https://grafana.com/docs/http_api/dashboard_public/
+type PublicDashboardListJSON []PublicDashboardJSON
+
+// PublicDashboardJSON is the public sharing configuration of a dashboard
+// More info: https://grafana.com/docs/http_api/dashboard_public/
+type PublicDashboardJSON struct {
+	UID          string `json:"uid"`
+	DashboardUID string `json:"dashboardUid"`
+	Title        string `json:"title,omitempty"`
+	AccessToken  string `json:"accessToken,omitempty"`
+	IsEnabled    bool   `json:"isEnabled"`
+	Share        string `json:"share,omitempty"`
+}
+
+// ErrPublicDashboardsNotSupported is returned when Grafana has no public dashboards API
+var ErrPublicDashboardsNotSupported = errors.New("Grafana does not support public dashboards")
+
+// GetPublicDashboards returns all public dashboards of the organisation,
+// ErrPublicDashboardsNotSupported for Grafana versions without them.
+// It reflects GET /api/dashboards/public-dashboards API call.
+// More info: https://grafana.com/docs/http_api/dashboard_public/
+func (r *Client) GetPublicDashboards() (PublicDashboardListJSON, error) {
+	var (
+		records PublicDashboardListJSON
+		raw     []byte
+		code    int
+		err     error
+	)
+
+	q := url.Values{}
+	q.Set("perpage", "10000")
+
+	raw, code, err = r.getRequest("/api/dashboards/public-dashboards", q)
+
+	if err != nil && code != 200 {
+		return records, err
+	}
+	if code == 404 {
+		return records, ErrPublicDashboardsNotSupported
+	}
+	if code != 200 {
+		return nil, fmt.Errorf("HTTP error %d: returns %s", code, raw)
+	}
+
+	return ParsePublicDashboards(raw)
+}
+
+// ParsePublicDashboards decodes the list of public dashboards as returned by
+// the Grafana API, newer Grafana versions wrap the list to support paging
+func ParsePublicDashboards(raw []byte) (PublicDashboardListJSON, error) {
+	var records PublicDashboardListJSON
+	if len(raw) > 0 && raw[0] == '{' {
+		result := struct {
+			PublicDashboards PublicDashboardListJSON `json:"publicDashboards"`
+		}{}
+		err := json.Unmarshal(raw, &result)
+		return result.PublicDashboards, err
+	}
+	err := json.Unmarshal(raw, &records)
+	return records, err
+}
+
+// OfDashboards returns the public dashboards of the dashboards with the given
+// UIDs. Access tokens are masked, only their existence is reported.
+func (l PublicDashboardListJSON) OfDashboards(dashboardUIDs []string) PublicDashboardListJSON {
+	selected := map[string]bool{}
+	for _, uid := range dashboardUIDs {
+		selected[uid] = true
+	}
+	result := PublicDashboardListJSON{}
+	for _, public := range l {
+		if !selected[public.DashboardUID] {
+			continue
+		}
+		if public.AccessToken != "" {
+			public.AccessToken = "***"
+		}
+		result = append(result, public)
+	}
+	return result
+}
+
+// GetPublicDashboard returns the public sharing configuration of a
+// dashboard, the UID is empty if the dashboard was never shared.
+// It reflects GET /api/dashboards/uid/:uid/public-dashboards API call.
+// More info: https://grafana.com/docs/http_api/dashboard_public/
+func (r *Client) GetPublicDashboard(dashboardUID string) (PublicDashboardJSON, error) {
+	var (
+		record PublicDashboardJSON
+		raw    []byte
+		code   int
+		err    error
+	)
+
+	raw, code, err = r.getRequest(fmt.Sprintf("/api/dashboards/uid/%s/public-dashboards", dashboardUID), nil)
+
+	if err != nil && code != 200 {
+		return record, err
+	}
+	if code == 404 {
+		return record, nil
+	}
+	if code != 200 {
+		return record, fmt.Errorf("HTTP error %d: returns %s", code, raw)
+	}
+
+	err = json.Unmarshal(raw, &record)
+	return record, err
+}
+
+// CreatePublicDashboard shares a dashboard publicly.
+// It reflects POST /api/dashboards/uid/:uid/public-dashboards API call.
+// More info: https://grafana.com/docs/http_api/dashboard_public/
+func (r *Client) CreatePublicDashboard(dashboardUID string, enabled bool) (PublicDashboardJSON, error) {
+	var record PublicDashboardJSON
+
+	body, err := json.Marshal(PublicDashboardJSON{IsEnabled: enabled, Share: "public"})
+	if err != nil {
+		return record, err
+	}
+
+	raw, code, err := r.postRequest(fmt.Sprintf("/api/dashboards/uid/%s/public-dashboards", dashboardUID), nil, body)
+
+	if err != nil && code != 200 {
+		return record, err
+	}
+	if code != 200 {
+		return record, fmt.Errorf("HTTP error %d: returns %s", code, raw)
+	}
+
+	err = json.Unmarshal(raw, &record)
+	return record, err
+}
+
+// UpdatePublicDashboard enables or disables the public access to a dashboard.
+// It reflects PATCH /api/dashboards/uid/:uid/public-dashboards/:publicUid API call.
+// More info: https://grafana.com/docs/http_api/dashboard_public/
+func (r *Client) UpdatePublicDashboard(dashboardUID string, UID string, enabled bool) (PublicDashboardJSON, error) {
+	var record PublicDashboardJSON
+
+	body, err := json.Marshal(map[string]bool{"isEnabled": enabled})
+	if err != nil {
+		return record, err
+	}
+
+	raw, code, err := r.patchRequest(fmt.Sprintf("/api/dashboards/uid/%s/public-dashboards/%s", dashboardUID, UID), nil, body)
+
+	if err != nil && code != 200 {
+		return record, err
+	}
+	if code != 200 {
+		return record, fmt.Errorf("HTTP error %d: returns %s", code, raw)
+	}
+
+	err = json.Unmarshal(raw, &record)
+	return record, err
+}
+
+// DeletePublicDashboard removes the public sharing of a dashboard and its access token.
+// It reflects DELETE /api/dashboards/uid/:uid/public-dashboards/:publicUid API call.
+// More info: https://grafana.com/docs/http_api/dashboard_public/
+func (r *Client) DeletePublicDashboard(dashboardUID string, UID string) error {
+	raw, code, err := r.deleteRequest(fmt.Sprintf("/api/dashboards/uid/%s/public-dashboards/%s", dashboardUID, UID))
+
+	if err != nil && code != 200 {
+		return err
+	}
+	if code != 200 {
+		return fmt.Errorf("HTTP error %d: returns %s", code, raw)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package grafana_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lstuker/grafana-tool/grafana"
+)
+
+func TestParsePublicDashboards(t *testing.T) {
+	tables := []struct {
+		raw  string
+		uids []string
+	}{
+		{`[{"uid":"p1","dashboardUid":"abc"},{"uid":"p2","dashboardUid":"def"}]`, []string{"p1", "p2"}},
+		{`{"publicDashboards":[{"uid":"p1","dashboardUid":"abc"}],"totalCount":1,"page":1,"perPage":10000}`, []string{"p1"}},
+		{`{"publicDashboards":[],"totalCount":0}`, []string{}},
+		{`[]`, []string{}},
+	}
+	for _, table := range tables {
+		list, err := grafana.ParsePublicDashboards([]byte(table.raw))
+		if err != nil {
+			t.Fatal(err)
+		}
+		uids := []string{}
+		for _, public := range list {
+			uids = append(uids, public.UID)
+		}
+		if strings.Join(uids, ",") != strings.Join(table.uids, ",") {
+			t.Errorf("Is was  incorrect, got: %v, want: %v.", uids, table.uids)
+		}
+	}
+
+	if _, err := grafana.ParsePublicDashboards([]byte(`{"publicDashboards":{}}`)); err == nil {
+		t.Errorf("Expected an error for an invalid list")
+	}
+}
+
+func TestPublicDashboardsOfDashboards(t *testing.T) {
+	list := grafana.PublicDashboardListJSON{
+		{UID: "p1", DashboardUID: "abc", AccessToken: "secret", IsEnabled: true},
+		{UID: "p2", DashboardUID: "def", IsEnabled: false},
+		{UID: "p3", DashboardUID: "ghi", AccessToken: "secret", IsEnabled: true},
+	}
+
+	tables := []struct {
+		dashboardUIDs []string
+		uids          []string
+	}{
+		{nil, []string{}},
+		{[]string{"abc"}, []string{"p1"}},
+		{[]string{"ghi", "def", "xyz"}, []string{"p2", "p3"}},
+	}
+	for _, table := range tables {
+		uids := []string{}
+		for _, public := range list.OfDashboards(table.dashboardUIDs) {
+			uids = append(uids, public.UID)
+			if public.AccessToken != "" && public.AccessToken != "***" {
+				t.Errorf("Is was  incorrect, got: %v, want: %v.", public.AccessToken, "***")
+			}
+		}
+		if strings.Join(uids, ",") != strings.Join(table.uids, ",") {
+			t.Errorf("Is was  incorrect, got: %v, want: %v.", uids, table.uids)
+		}
+	}
+	if list[0].AccessToken != "secret" {
+		t.Errorf("Expected the access token of the list to be kept")
+	}
+}
@@ -0,0 +1,436 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grafana
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Query is the query of a target in the language of its datasource
+type Query struct {
+	RefID          string `json:"refId"`
+	DatasourceType string `json:"datasourceType"`
+	// Field is the key of the target with the query text, it is empty if
+	// the text is built from other keys like InfluxQL of the query editor
+	Field string `json:"field"`
+	Text  string `json:"text"`
+}
+
+// PanelQuery is a query of a panel of a dashboard
+type PanelQuery struct {
+	Panel  *Panel
+	Target *Target
+	Query
+}
+
+// queryFields are the keys with the query text by datasource type
+var queryFields = map[string]string{
+	"prometheus":                    "expr",
+	"loki":                          "expr",
+	"elasticsearch":                 "query",
+	"mysql":                         "rawSql",
+	"postgres":                      "rawSql",
+	"grafana-postgresql-datasource": "rawSql",
+	"mssql":                         "rawSql",
+	"graphite":                      "target",
+	"tempo":                         "query",
+	"jaeger":                        "query",
+}
+
+// Queries returns the queries of all panels, including the panels of
+// collapsed rows. The datasource type of a target is resolved with the
+// datasources, targets without datasource use the one of the panel or the
+// default datasource. Unresolved types are guessed from the target keys.
+func (d *DashboardJSON) Queries(datasources DatasourceListJSON) []PanelQuery {
+	queries := []PanelQuery{}
+	d.WalkPanels(func(panel *Panel, row *Panel) {
+		for i := range panel.Targets {
+			target := &panel.Targets[i]
+			targetType := queryDatasourceType(panel.Datasource, target.Datasource, datasources)
+			queries = append(queries, PanelQuery{Panel: panel, Target: target, Query: target.EffectiveQuery(targetType)})
+		}
+	})
+	return queries
+}
+
+// QueryChange is a query rewritten by ReplaceQueries, Old and New are the
+// query texts
+type QueryChange struct {
+	PanelID        int    `json:"panelId"`
+	Panel          string `json:"panel"`
+	RefID          string `json:"refId"`
+	DatasourceType string `json:"datasourceType"`
+	Field          string `json:"field"`
+	Old            string `json:"old"`
+	New            string `json:"new"`
+}
+
+// ReplaceQueries replaces the matches of re in the queries of a dashboard,
+// including the panels of collapsed rows, like regexp ReplaceAllString.
+// Queries built by a query editor are rewritten in their measurement, field
+// and metric names. If datasourceType is not empty only its queries are
+// changed.
+func ReplaceQueries(dashboard map[string]interface{}, re *regexp.Regexp, replacement string, datasourceType string, datasources DatasourceListJSON) []QueryChange {
+	changes := []QueryChange{}
+	walkPanelMaps(dashboard, func(panel map[string]interface{}) {
+		targets, _ := panel["targets"].([]interface{})
+		for i, item := range targets {
+			target, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			targetType := queryDatasourceType(panel["datasource"], target["datasource"], datasources)
+			query := typedTarget(target).EffectiveQuery(targetType)
+			if datasourceType != "" && query.DatasourceType != datasourceType {
+				continue
+			}
+
+			// the copy is only written back if the query text changed, so
+			// no other key is changed without a change reported
+			replaced := copyJSON(target)
+			text := re.ReplaceAllString(query.Text, replacement)
+			if query.Field != "" {
+				replaced[query.Field] = text
+			} else {
+				replaceBuiltQuery(replaced, re, replacement)
+				text = typedTarget(replaced).EffectiveQuery(query.DatasourceType).Text
+			}
+			if text == query.Text {
+				continue
+			}
+			targets[i] = replaced
+
+			title, _ := panel["title"].(string)
+			id, _ := panel["id"].(float64)
+			changes = append(changes, QueryChange{
+				PanelID:        int(id),
+				Panel:          title,
+				RefID:          query.RefID,
+				DatasourceType: query.DatasourceType,
+				Field:          query.Field,
+				Old:            query.Text,
+				New:            text,
+			})
+		}
+	})
+	return changes
+}
+
+// replaceBuiltQuery replaces the matches of re in the names a query editor
+// builds the query from, the measurement and the params of select and group
+// by of InfluxDB and the namespace and metric name of CloudWatch
+func replaceBuiltQuery(target map[string]interface{}, re *regexp.Regexp, replacement string) {
+	for _, key := range []string{"measurement", "namespace", "metricName"} {
+		if value, ok := target[key].(string); ok {
+			target[key] = re.ReplaceAllString(value, replacement)
+		}
+	}
+	var replaceParams func(parts interface{})
+	replaceParams = func(parts interface{}) {
+		switch value := parts.(type) {
+		case []interface{}:
+			for _, part := range value {
+				replaceParams(part)
+			}
+		case map[string]interface{}:
+			params, _ := value["params"].([]interface{})
+			for i, param := range params {
+				if text, ok := param.(string); ok {
+					params[i] = re.ReplaceAllString(text, replacement)
+				}
+			}
+		}
+	}
+	replaceParams(target["select"])
+	replaceParams(target["groupBy"])
+}
+
+// queryDatasourceType returns the datasource type of a target, targets
+// without datasource use the one of the panel and panels without datasource
+// the default datasource
+func queryDatasourceType(panelRef interface{}, targetRef interface{}, datasources DatasourceListJSON) string {
+	if targetRef != nil {
+		targetType, _ := datasourceType(targetRef, datasources)
+		return targetType
+	}
+	if panelRef == nil {
+		for _, datasource := range datasources {
+			if datasource.IsDefault {
+				return datasource.Type
+			}
+		}
+		return ""
+	}
+	panelType, _ := datasourceType(panelRef, datasources)
+	return panelType
+}
+
+// typedTarget returns the typed form of a raw target
+func typedTarget(target map[string]interface{}) Target {
+	var typed Target
+	raw, err := json.Marshal(target)
+	if err != nil {
+		return typed
+	}
+	json.Unmarshal(raw, &typed)
+	return typed
+}
+
+// EffectiveQuery returns the query text of the target for the datasource
+// type, an empty type is guessed from the keys of the target
+func (t Target) EffectiveQuery(datasourceType string) Query {
+	if datasourceType == "" {
+		datasourceType = t.guessDatasourceType()
+	}
+	query := Query{RefID: t.RefID, DatasourceType: datasourceType}
+
+	switch datasourceType {
+	case "influxdb":
+		if t.RawQuery || (t.Query != "" && len(t.Select) == 0 && t.Measurement == "") {
+			query.Field, query.Text = "query", t.Query
+		} else {
+			query.Text = t.InfluxQL()
+		}
+	case "cloudwatch":
+		switch {
+		case t.Expression != "":
+			query.Field, query.Text = "expression", t.Expression
+		case t.SQLExpression != "":
+			query.Field, query.Text = "sqlExpression", t.SQLExpression
+		default:
+			query.Text = t.cloudWatchMetric()
+		}
+	default:
+		field, ok := queryFields[datasourceType]
+		if !ok {
+			for _, key := range []string{"expr", "rawSql", "query", "target", "expression"} {
+				if t.stringValue(key) != "" {
+					field = key
+					break
+				}
+			}
+		}
+		query.Field, query.Text = field, t.stringValue(field)
+	}
+	return query
+}
+
+// guessDatasourceType returns the datasource type the keys of the target
+// belong to, ex: rawSql to mysql
+func (t Target) guessDatasourceType() string {
+	_, metrics := t.Extra["metrics"]
+	_, bucketAggs := t.Extra["bucketAggs"]
+	switch {
+	case t.Expr != "":
+		if strings.HasPrefix(strings.TrimSpace(t.Expr), "{") {
+			return "loki"
+		}
+		return "prometheus"
+	case t.RawSQL != "":
+		return "mysql"
+	case t.Measurement != "" || len(t.Select) > 0 || t.RawQuery:
+		return "influxdb"
+	case t.Namespace != "" || t.Expression != "" || t.SQLExpression != "":
+		return "cloudwatch"
+	case metrics || bucketAggs:
+		return "elasticsearch"
+	case t.stringValue("target") != "":
+		return "graphite"
+	}
+	return ""
+}
+
+// stringValue returns the string value of a key of the target
+func (t Target) stringValue(key string) string {
+	switch key {
+	case "":
+		return ""
+	case "expr":
+		return t.Expr
+	case "rawSql":
+		return t.RawSQL
+	case "query":
+		return t.Query
+	case "expression":
+		return t.Expression
+	case "sqlExpression":
+		return t.SQLExpression
+	}
+	var value string
+	json.Unmarshal(t.Extra[key], &value)
+	return value
+}
+
+// InfluxQL returns the InfluxQL query the InfluxDB query editor builds from
+// Measurement, Select, Tags and GroupBy
+func (t Target) InfluxQL() string {
+	selects := []string{}
+	for _, parts := range t.Select {
+		expression := ""
+		for _, part := range parts {
+			params := []string{}
+			for _, param := range part.Params {
+				params = append(params, fmt.Sprint(param))
+			}
+			expression = influxPart(part.Type, params, expression)
+		}
+		selects = append(selects, expression)
+	}
+
+	measurement := t.Measurement
+	if !isRegex(measurement) {
+		measurement = `"` + measurement + `"`
+	}
+	if t.Policy != "" && t.Policy != "default" {
+		measurement = `"` + t.Policy + `".` + measurement
+	}
+
+	query := "SELECT " + strings.Join(selects, ", ") + " FROM " + measurement + " WHERE "
+	conditions := []string{}
+	for i, item := range t.Tags {
+		tag, _ := item.(map[string]interface{})
+		conditions = append(conditions, influxTagCondition(tag, i))
+	}
+	if len(conditions) > 0 {
+		query += "(" + strings.Join(conditions, " ") + ") AND "
+	}
+	query += "$timeFilter"
+
+	groupBy := ""
+	for i, part := range t.GroupBy {
+		if i > 0 {
+			if part.Type == "fill" {
+				groupBy += " "
+			} else {
+				groupBy += ", "
+			}
+		}
+		params := []string{}
+		for _, param := range part.Params {
+			params = append(params, fmt.Sprint(param))
+		}
+		groupBy += influxPart(part.Type, params, "")
+	}
+	if groupBy != "" {
+		query += " GROUP BY " + groupBy
+	}
+	if t.Fill != "" {
+		query += " fill(" + t.Fill + ")"
+	}
+	if t.OrderByTime == "DESC" {
+		query += " ORDER BY time DESC"
+	}
+	if t.Limit != nil && t.Limit != "" {
+		query += fmt.Sprintf(" LIMIT %v", t.Limit)
+	}
+	if t.SLimit != nil && t.SLimit != "" {
+		query += fmt.Sprintf(" SLIMIT %v", t.SLimit)
+	}
+	if t.Tz != "" {
+		query += " tz('" + t.Tz + "')"
+	}
+	return query
+}
+
+// influxPart renders a part of the InfluxDB query editor around the inner
+// expression like the editor does
+func influxPart(partType string, params []string, inner string) string {
+	switch partType {
+	case "field", "tag":
+		if len(params) == 0 {
+			return inner
+		}
+		if params[0] == "*" {
+			return "*"
+		}
+		return `"` + params[0] + `"`
+	case "math":
+		if len(params) == 0 {
+			return inner
+		}
+		return inner + " " + params[0]
+	case "alias":
+		if len(params) == 0 {
+			return inner
+		}
+		return inner + ` AS "` + params[0] + `"`
+	}
+	if inner != "" {
+		params = append([]string{inner}, params...)
+	}
+	return partType + "(" + strings.Join(params, ", ") + ")"
+}
+
+// influxTagCondition renders a tag filter of the InfluxDB query editor
+func influxTagCondition(tag map[string]interface{}, index int) string {
+	key, _ := tag["key"].(string)
+	operator, _ := tag["operator"].(string)
+	value := fmt.Sprint(tag["value"])
+
+	condition := ""
+	if index > 0 {
+		if keyword, _ := tag["condition"].(string); keyword != "" {
+			condition = keyword + " "
+		} else {
+			condition = "AND "
+		}
+	}
+	if operator == "" {
+		operator = "="
+		if isRegex(value) {
+			operator = "=~"
+		}
+	}
+	if operator != "=~" && operator != "!~" && operator != "<" && operator != ">" {
+		value = "'" + strings.Replace(strings.Replace(value, `\`, `\\`, -1), "'", `\'`, -1) + "'"
+	}
+	return condition + `"` + key + `" ` + operator + " " + value
+}
+
+var regexValue = regexp.MustCompile(`^/.*/$`)
+
+// isRegex returns true for InfluxDB regular expressions like /^cpu/
+func isRegex(value string) bool {
+	return regexValue.MatchString(value)
+}
+
+// cloudWatchMetric returns a CloudWatch metric query of the query editor as
+// text, ex: AWS/EC2 CPUUtilization Average InstanceId=i-1 region=eu-west-1
+func (t Target) cloudWatchMetric() string {
+	parts := []string{}
+	for _, part := range []string{t.Namespace, t.MetricName, t.Statistic} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	if t.Statistic == "" {
+		parts = append(parts, t.Statistics...)
+	}
+
+	dimensions := []string{}
+	for name, value := range t.Dimensions {
+		dimensions = append(dimensions, fmt.Sprintf("%s=%v", name, value))
+	}
+	sort.Strings(dimensions)
+	parts = append(parts, dimensions...)
+	if t.Region != "" && t.Region != "default" {
+		parts = append(parts, "region="+t.Region)
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,133 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package grafana_test
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+
+	"github.com/lstuker/grafana-tool/grafana"
+)
+
+func TestDashboardQueries(t *testing.T) {
+	var dashboard grafana.DashboardJSON
+	err := json.Unmarshal([]byte(`{"title":"Linux","panels":[
+		{"id":1,"type":"timeseries","datasource":{"type":"prometheus","uid":"prom1"},
+			"targets":[{"refId":"A","expr":"rate(node_cpu_seconds_total[5m])"}]},
+		{"id":2,"type":"graph","datasource":"influx","targets":[
+			{"refId":"A","measurement":"cpu","policy":"autogen","orderByTime":"ASC",
+				"select":[[{"type":"field","params":["usage_idle"]},{"type":"mean","params":[]},{"type":"math","params":["* -1"]},{"type":"alias","params":["idle"]}]],
+				"tags":[{"key":"host","operator":"=~","value":"/^$host$/"},{"condition":"AND","key":"cpu","operator":"=","value":"cpu-total"}],
+				"groupBy":[{"type":"time","params":["$__interval"]},{"type":"tag","params":["host"]},{"type":"fill","params":["null"]}]},
+			{"refId":"B","rawQuery":true,"query":"SELECT last(\"value\") FROM \"load\""}]},
+		{"id":3,"type":"row","collapsed":true,"panels":[
+			{"id":4,"type":"table","datasource":"-- Mixed --","targets":[
+				{"refId":"A","datasource":{"type":"mysql","uid":"sql1"},"rawSql":"SELECT 1"},
+				{"refId":"B","datasource":"logs","expr":"{job=\"varlogs\"} |= \"error\""},
+				{"refId":"C","datasource":"es","query":"status:500","metrics":[{"type":"count"}]},
+				{"refId":"D","datasource":{"type":"cloudwatch","uid":"cw"},"namespace":"AWS/EC2","metricName":"CPUUtilization",
+					"statistic":"Average","dimensions":{"InstanceId":"i-1"},"region":"eu-west-1"},
+				{"refId":"E","datasource":{"type":"cloudwatch","uid":"cw"},"expression":"SUM(METRICS())"},
+				{"refId":"F","datasource":"graphite","target":"aliasByNode(servers.*.cpu, 1)"}]}]},
+		{"id":5,"type":"stat","targets":[{"refId":"A","expr":"up"}]}]}`), &dashboard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	datasources := grafana.DatasourceListJSON{
+		{Name: "influx", Type: "influxdb"},
+		{Name: "graphite", Type: "graphite"},
+		{Name: "Thanos", Type: "prometheus", IsDefault: true},
+	}
+
+	tables := []grafana.Query{
+		{RefID: "A", DatasourceType: "prometheus", Field: "expr", Text: "rate(node_cpu_seconds_total[5m])"},
+		{RefID: "A", DatasourceType: "influxdb", Field: "",
+			Text: `SELECT mean("usage_idle") * -1 AS "idle" FROM "autogen"."cpu" WHERE ("host" =~ /^$host$/ AND "cpu" = 'cpu-total') AND $timeFilter GROUP BY time($__interval), "host" fill(null)`},
+		{RefID: "B", DatasourceType: "influxdb", Field: "query", Text: `SELECT last("value") FROM "load"`},
+		{RefID: "A", DatasourceType: "mysql", Field: "rawSql", Text: "SELECT 1"},
+		{RefID: "B", DatasourceType: "loki", Field: "expr", Text: `{job="varlogs"} |= "error"`},
+		{RefID: "C", DatasourceType: "elasticsearch", Field: "query", Text: "status:500"},
+		{RefID: "D", DatasourceType: "cloudwatch", Field: "", Text: "AWS/EC2 CPUUtilization Average InstanceId=i-1 region=eu-west-1"},
+		{RefID: "E", DatasourceType: "cloudwatch", Field: "expression", Text: "SUM(METRICS())"},
+		{RefID: "F", DatasourceType: "graphite", Field: "target", Text: "aliasByNode(servers.*.cpu, 1)"},
+		{RefID: "A", DatasourceType: "prometheus", Field: "expr", Text: "up"},
+	}
+
+	queries := dashboard.Queries(datasources)
+	if len(queries) != len(tables) {
+		t.Fatalf("Is was  incorrect, got: %v, want: %v.", len(queries), len(tables))
+	}
+	for i, table := range tables {
+		if queries[i].Query != table {
+			t.Errorf("Is was  incorrect, got: %v, want: %v.", queries[i].Query, table)
+		}
+	}
+	if queries[3].Panel.ID != 4 {
+		t.Errorf("Is was  incorrect, got: %v, want: %v.", queries[3].Panel.ID, 4)
+	}
+}
+
+func TestReplaceQueries(t *testing.T) {
+	var dashboard map[string]interface{}
+	json.Unmarshal([]byte(`{"title":"Linux","panels":[
+		{"id":1,"type":"timeseries","title":"CPU","datasource":{"type":"prometheus","uid":"prom1"},"targets":[
+			{"refId":"A","expr":"rate(node_cpu[5m])"},
+			{"refId":"B","expr":"node_load1"}]},
+		{"id":2,"type":"row","collapsed":true,"panels":[
+			{"id":3,"type":"graph","title":"Idle","datasource":"influx","targets":[
+				{"refId":"A","measurement":"node_cpu","select":[[{"type":"field","params":["node_cpu"]},{"type":"mean","params":[]}]]},
+				{"refId":"B","datasource":{"type":"loki","uid":"loki1"},"expr":"{job=\"node_cpu\"}"}]}]}]}`), &dashboard)
+	datasources := grafana.DatasourceListJSON{{Name: "influx", Type: "influxdb"}}
+
+	changes := grafana.ReplaceQueries(dashboard, regexp.MustCompile(`\bnode_cpu\b`), "node_cpu_seconds_total", "", datasources)
+	if len(changes) != 3 {
+		t.Fatalf("Is was  incorrect, got: %v, want: %v.", changes, 3)
+	}
+	expect := grafana.QueryChange{PanelID: 1, Panel: "CPU", RefID: "A", DatasourceType: "prometheus", Field: "expr",
+		Old: "rate(node_cpu[5m])", New: "rate(node_cpu_seconds_total[5m])"}
+	if changes[0] != expect {
+		t.Errorf("Is was  incorrect, got: %v, want: %v.", changes[0], expect)
+	}
+	if got := changes[1].New; got != `SELECT mean("node_cpu_seconds_total") FROM "node_cpu_seconds_total" WHERE $timeFilter` {
+		t.Errorf("Is was  incorrect, got: %v, want: %v.", got, "the built InfluxQL with the new names")
+	}
+
+	row := dashboard["panels"].([]interface{})[1].(map[string]interface{})
+	target := row["panels"].([]interface{})[0].(map[string]interface{})["targets"].([]interface{})[1].(map[string]interface{})
+	if got := target["expr"]; got != `{job="node_cpu_seconds_total"}` {
+		t.Errorf("Is was  incorrect, got: %v, want: %v.", got, `{job="node_cpu_seconds_total"}`)
+	}
+
+	changes = grafana.ReplaceQueries(dashboard, regexp.MustCompile(`node_load1`), "node_load5", "loki", datasources)
+	if len(changes) != 0 {
+		t.Errorf("Is was  incorrect, got: %v, want: %v.", changes, 0)
+	}
+}
+
+func TestReplaceQueriesUnchangedText(t *testing.T) {
+	var dashboard map[string]interface{}
+	json.Unmarshal([]byte(`{"panels":[{"id":1,"type":"graph","datasource":"unknown","targets":[
+		{"refId":"A","metricName":"cpu"}]}]}`), &dashboard)
+	before, _ := json.Marshal(dashboard)
+
+	changes := grafana.ReplaceQueries(dashboard, regexp.MustCompile(`cpu`), "processor", "", nil)
+	after, _ := json.Marshal(dashboard)
+	if len(changes) != 0 {
+		t.Errorf("Is was  incorrect, got: %v, want: %v.", changes, 0)
+	}
+	if string(before) != string(after) {
+		t.Errorf("Is was  incorrect, got: %s, want: %s.", after, before)
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"net/http"
 	"net/url"
 	"path"
+	"strconv"
 )
 
 // Client uses Grafana REST API for interacting with Grafana server.
@@ -32,6 +33,7 @@ type Client struct {
 	apiToken   string
 	username   string
 	password   string
+	orgID      int
 	httpClient *http.Client
 }
 
@@ -47,6 +49,14 @@ func NewClient(apiURL, apiToken string, username string, password string, client
 		httpClient: client}
 }
 
+// WithOrg returns a copy of the client which sends its requests to the given
+// organisation instead of the current organisation of the user.
+func (r *Client) WithOrg(orgID int) *Client {
+	c := *r
+	c.orgID = orgID
+	return &c
+}
+
 func (r *Client) getRequest(query string, params url.Values) ([]byte, int, error) {
 	return r.request("GET", query, params, nil)
 }
@@ -55,6 +65,14 @@ func (r *Client) postRequest(query string, params url.Values, body []byte) ([]by
 	return r.request("POST", query, params, bytes.NewBuffer(body))
 }
 
+func (r *Client) putRequest(query string, params url.Values, body []byte) ([]byte, int, error) {
+	return r.request("PUT", query, params, bytes.NewBuffer(body))
+}
+
+func (r *Client) patchRequest(query string, params url.Values, body []byte) ([]byte, int, error) {
+	return r.request("PATCH", query, params, bytes.NewBuffer(body))
+}
+
 func (r *Client) deleteRequest(query string) ([]byte, int, error) {
 	return r.request("DELETE", query, nil, nil)
 }
@@ -77,6 +95,10 @@ func (r *Client) request(method, query string, params url.Values, buf io.Reader)
 		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", r.apiToken))
 	}
 
+	if r.orgID != 0 {
+		req.Header.Set("X-Grafana-Org-Id", strconv.Itoa(r.orgID))
+	}
+
 	log.Printf("Request: %s\n", u.String())
 
 	req.Header.Add("Cache-Control", "no-cache")
@@ -0,0 +1,235 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grafana
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// ServiceAccountListJSON is a list of service accounts from the Grafana API
+// More info: https://grafana.com/docs/http_api/serviceaccount/
+type ServiceAccountListJSON []ServiceAccountJSON
+
+// ServiceAccountJSON is a service account from the Grafana API
+// More info: https://grafana.com/docs/http_api/serviceaccount/
+type ServiceAccountJSON struct {
+	ID         int    `json:"id"`
+	Name       string `json:"name"`
+	Login      string `json:"login"`
+	OrgID      int    `json:"orgId"`
+	IsDisabled bool   `json:"isDisabled"`
+	Role       string `json:"role"`
+	Tokens     int    `json:"tokens"`
+}
+
+// ServiceAccountTokenListJSON is a list of service account tokens from the Grafana API
+// More info: https://grafana.com/docs/http_api/serviceaccount/
+type ServiceAccountTokenListJSON []ServiceAccountTokenJSON
+
+// ServiceAccountTokenJSON is a service account token from the Grafana API.
+// The key itself is only returned once when the token is created.
+// More info: https://grafana.com/docs/http_api/serviceaccount/
+type ServiceAccountTokenJSON struct {
+	ID         int        `json:"id"`
+	Name       string     `json:"name"`
+	Created    time.Time  `json:"created"`
+	LastUsedAt *time.Time `json:"lastUsedAt"`
+	Expiration *time.Time `json:"expiration"`
+	HasExpired bool       `json:"hasExpired"`
+	IsRevoked  bool       `json:"isRevoked"`
+	Key        string     `json:"key,omitempty"`
+}
+
+// GetServiceAccounts returns all service accounts of the organisation.
+// It reflects GET /api/serviceaccounts/search API call.
+// More info: https://grafana.com/docs/http_api/serviceaccount/
+func (r *Client) GetServiceAccounts() (ServiceAccountListJSON, error) {
+	var (
+		raw  []byte
+		code int
+		err  error
+	)
+
+	q := url.Values{}
+	q.Set("perpage", "10000")
+
+	raw, code, err = r.getRequest("/api/serviceaccounts/search", q)
+
+	records := struct {
+		ServiceAccounts ServiceAccountListJSON `json:"serviceAccounts"`
+	}{}
+
+	if err != nil && code != 200 {
+		return records.ServiceAccounts, err
+	}
+	if code != 200 {
+		return nil, fmt.Errorf("HTTP error %d: returns %s", code, raw)
+	}
+
+	err = json.Unmarshal(raw, &records)
+	return records.ServiceAccounts, err
+}
+
+// CreateServiceAccount creates a service account with the given role.
+// It reflects POST /api/serviceaccounts API call.
+// More info: https://grafana.com/docs/http_api/serviceaccount/
+func (r *Client) CreateServiceAccount(name string, role string) (ServiceAccountJSON, error) {
+	var record ServiceAccountJSON
+
+	body, err := json.Marshal(map[string]string{"name": name, "role": role})
+	if err != nil {
+		return record, err
+	}
+
+	raw, code, err := r.postRequest("/api/serviceaccounts", nil, body)
+
+	if err != nil && code != 201 && code != 200 {
+		return record, err
+	}
+	if code != 201 && code != 200 {
+		return record, fmt.Errorf("HTTP error %d: returns %s", code, raw)
+	}
+
+	err = json.Unmarshal(raw, &record)
+	return record, err
+}
+
+// DeleteServiceAccount deletes the service account with the given ID and all its tokens.
+// It reflects DELETE /api/serviceaccounts/:id API call.
+// More info: https://grafana.com/docs/http_api/serviceaccount/
+func (r *Client) DeleteServiceAccount(ID int) error {
+	raw, code, err := r.deleteRequest(fmt.Sprintf("/api/serviceaccounts/%d", ID))
+
+	if err != nil && code != 200 {
+		return err
+	}
+	if code != 200 {
+		return fmt.Errorf("HTTP error %d: returns %s", code, raw)
+	}
+	return nil
+}
+
+// GetServiceAccountTokens returns the tokens of a service account.
+// It reflects GET /api/serviceaccounts/:id/tokens API call.
+// More info: https://grafana.com/docs/http_api/serviceaccount/
+func (r *Client) GetServiceAccountTokens(serviceAccountID int) (ServiceAccountTokenListJSON, error) {
+	var (
+		records ServiceAccountTokenListJSON
+		raw     []byte
+		code    int
+		err     error
+	)
+
+	raw, code, err = r.getRequest(fmt.Sprintf("/api/serviceaccounts/%d/tokens", serviceAccountID), nil)
+
+	if err != nil && code != 200 {
+		return records, err
+	}
+	if code != 200 {
+		return nil, fmt.Errorf("HTTP error %d: returns %s", code, raw)
+	}
+
+	err = json.Unmarshal(raw, &records)
+	return records, err
+}
+
+// CreateServiceAccountToken creates a token, a zero secondsToLive creates a
+// token which never expires. The returned token contains the key.
+// It reflects POST /api/serviceaccounts/:id/tokens API call.
+// More info: https://grafana.com/docs/http_api/serviceaccount/
+func (r *Client) CreateServiceAccountToken(serviceAccountID int, name string, secondsToLive int) (ServiceAccountTokenJSON, error) {
+	var record ServiceAccountTokenJSON
+
+	payload := map[string]interface{}{"name": name}
+	if secondsToLive != 0 {
+		payload["secondsToLive"] = secondsToLive
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return record, err
+	}
+
+	raw, code, err := r.postRequest(fmt.Sprintf("/api/serviceaccounts/%d/tokens", serviceAccountID), nil, body)
+
+	if err != nil && code != 200 {
+		return record, err
+	}
+	if code != 200 {
+		return record, fmt.Errorf("HTTP error %d: returns %s", code, raw)
+	}
+
+	err = json.Unmarshal(raw, &record)
+	return record, err
+}
+
+// DeleteServiceAccountToken revokes a token of a service account.
+// It reflects DELETE /api/serviceaccounts/:id/tokens/:tokenId API call.
+// More info: https://grafana.com/docs/http_api/serviceaccount/
+func (r *Client) DeleteServiceAccountToken(serviceAccountID int, tokenID int) error {
+	raw, code, err := r.deleteRequest(fmt.Sprintf("/api/serviceaccounts/%d/tokens/%d", serviceAccountID, tokenID))
+
+	if err != nil && code != 200 {
+		return err
+	}
+	if code != 200 {
+		return fmt.Errorf("HTTP error %d: returns %s", code, raw)
+	}
+	return nil
+}
+
+// ServiceAccountFind search in a ServiceAccountListJSON the service account
+// by name or ID and returns the ServiceAccountJSON object
+func (s ServiceAccountListJSON) ServiceAccountFind(nameOrID string) (ServiceAccountJSON, error) {
+	var empty ServiceAccountJSON
+
+	for _, account := range s {
+		if account.Name == nameOrID || strconv.Itoa(account.ID) == nameOrID {
+			return account, nil
+		}
+	}
+	return empty, errors.New("Service account not found")
+}
+
+// TokenFind search in a ServiceAccountTokenListJSON the token by name or ID
+// and returns the ServiceAccountTokenJSON object
+func (t ServiceAccountTokenListJSON) TokenFind(nameOrID string) (ServiceAccountTokenJSON, error) {
+	var empty ServiceAccountTokenJSON
+
+	for _, token := range t {
+		if token.Name == nameOrID || strconv.Itoa(token.ID) == nameOrID {
+			return token, nil
+		}
+	}
+	return empty, errors.New("Token not found")
+}
+
+// ExpiresWithin reports whether the token expires before now + d, tokens
+// which already expired included. Tokens without expiration never expire.
+func (t ServiceAccountTokenJSON) ExpiresWithin(now time.Time, d time.Duration) bool {
+	if t.Expiration == nil {
+		return false
+	}
+	return t.Expiration.Before(now.Add(d))
+}
+
+// NeverUsed reports whether the token was never used
+func (t ServiceAccountTokenJSON) NeverUsed() bool {
+	return t.LastUsedAt == nil || t.LastUsedAt.IsZero()
+}
@@ -0,0 +1,58 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package grafana_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/lstuker/grafana-tool/grafana"
+)
+
+func TestTokenExpiry(t *testing.T) {
+	var tokens grafana.ServiceAccountTokenListJSON
+	tokens_str := []byte(`[
+		{"id":1,"name":"ci","expiration":"2019-03-12T00:00:00Z","lastUsedAt":"2019-03-09T00:00:00Z"},
+		{"id":2,"name":"deploy","expiration":"2019-06-01T00:00:00Z","lastUsedAt":null},
+		{"id":3,"name":"forever","expiration":null},
+		{"id":4,"name":"expired","expiration":"2019-03-01T00:00:00Z","lastUsedAt":"2019-02-28T00:00:00Z"}]`)
+	json.Unmarshal(tokens_str, &tokens)
+	now := time.Date(2019, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	tables := []struct {
+		name      string
+		expiring  bool
+		neverUsed bool
+	}{
+		{"ci", true, false},
+		{"deploy", false, true},
+		{"forever", false, true},
+		{"expired", true, false},
+	}
+
+	for _, table := range tables {
+		token, err := tokens.TokenFind(table.name)
+		if err != nil {
+			t.Errorf("Token %s not found", table.name)
+			continue
+		}
+		if token.ExpiresWithin(now, 7*24*time.Hour) != table.expiring {
+			t.Errorf("Is was  incorrect for %s, got: %t, want: %t.", table.name, !table.expiring, table.expiring)
+		}
+		if token.NeverUsed() != table.neverUsed {
+			t.Errorf("Is was  incorrect for %s, got: %t, want: %t.", table.name, !table.neverUsed, table.neverUsed)
+		}
+	}
+}
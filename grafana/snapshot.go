@@ -0,0 +1,184 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grafana
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// SnapshotListJSON is a list of snapshots from the Grafana API
+// More info: https://grafana.com/docs/http_api/snapshot/
+type SnapshotListJSON []SnapshotJSON
+
+// SnapshotJSON is a snapshot from the Grafana API
+// More info: https://grafana.com/docs/http_api/snapshot/
+type SnapshotJSON struct {
+	ID          int       `json:"id"`
+	Name        string    `json:"name"`
+	Key         string    `json:"key"`
+	OrgID       int       `json:"orgId"`
+	UserID      int       `json:"userId"`
+	External    bool      `json:"external"`
+	ExternalURL string    `json:"externalUrl"`
+	Expires     time.Time `json:"expires"`
+	Created     time.Time `json:"created"`
+	Updated     time.Time `json:"updated"`
+}
+
+// SnapshotCreateResultJSON is the answer of Grafana to a created snapshot
+// More info: https://grafana.com/docs/http_api/snapshot/
+type SnapshotCreateResultJSON struct {
+	ID        int    `json:"id"`
+	Key       string `json:"key"`
+	URL       string `json:"url"`
+	DeleteKey string `json:"deleteKey"`
+	DeleteURL string `json:"deleteUrl"`
+}
+
+// GetSnapshots returns all snapshots of the organisation.
+// It reflects GET /api/dashboard/snapshots API call.
+// More info: https://grafana.com/docs/http_api/snapshot/
+func (r *Client) GetSnapshots() (SnapshotListJSON, error) {
+	var (
+		records SnapshotListJSON
+		raw     []byte
+		code    int
+		err     error
+	)
+
+	q := url.Values{}
+	q.Set("limit", "10000")
+
+	raw, code, err = r.getRequest("/api/dashboard/snapshots", q)
+
+	if err != nil && code != 200 {
+		return records, err
+	}
+	if code != 200 {
+		return nil, fmt.Errorf("HTTP error %d: returns %s", code, raw)
+	}
+
+	err = json.Unmarshal(raw, &records)
+	return records, err
+}
+
+// CreateSnapshot creates a snapshot of a dashboard, a zero expires creates
+// a snapshot which never expires.
+// It reflects POST /api/snapshots API call.
+// More info: https://grafana.com/docs/http_api/snapshot/
+func (r *Client) CreateSnapshot(dashboard interface{}, name string, expires time.Duration) (SnapshotCreateResultJSON, error) {
+	var record SnapshotCreateResultJSON
+
+	payload := map[string]interface{}{"dashboard": dashboard}
+	if name != "" {
+		payload["name"] = name
+	}
+	if expires != 0 {
+		payload["expires"] = int(expires.Seconds())
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return record, err
+	}
+
+	raw, code, err := r.postRequest("/api/snapshots", nil, body)
+
+	if err != nil && code != 200 {
+		return record, err
+	}
+	if code != 200 {
+		return record, fmt.Errorf("HTTP error %d: returns %s", code, raw)
+	}
+
+	err = json.Unmarshal(raw, &record)
+	return record, err
+}
+
+// DeleteSnapshot deletes the snapshot with the given key.
+// It reflects DELETE /api/snapshots/:key API call.
+// More info: https://grafana.com/docs/http_api/snapshot/
+func (r *Client) DeleteSnapshot(key string) error {
+	raw, code, err := r.deleteRequest(fmt.Sprintf("/api/snapshots/%s", key))
+
+	if err != nil && code != 200 {
+		return err
+	}
+	if code != 200 {
+		return fmt.Errorf("HTTP error %d: returns %s", code, raw)
+	}
+	return nil
+}
+
+// CreatedBefore returns the snapshots created before t
+func (s SnapshotListJSON) CreatedBefore(t time.Time) SnapshotListJSON {
+	snapshots := SnapshotListJSON{}
+	for _, snapshot := range s {
+		if snapshot.Created.Before(t) {
+			snapshots = append(snapshots, snapshot)
+		}
+	}
+	return snapshots
+}
+
+// CreatedByDeparted returns the snapshots whose creator is disabled in the
+// given list of users or confirmed deleted. Snapshots created with API keys
+// or anonymously, user ID 0, and by service accounts are never returned.
+func (s SnapshotListJSON) CreatedByDeparted(users UserListJSON, serviceAccounts ServiceAccountListJSON, deleted []int) SnapshotListJSON {
+	departed := map[int]bool{}
+	for _, user := range users {
+		if user.IsDisabled {
+			departed[user.ID] = true
+		}
+	}
+	for _, ID := range deleted {
+		departed[ID] = true
+	}
+	for _, serviceAccount := range serviceAccounts {
+		delete(departed, serviceAccount.ID)
+	}
+
+	snapshots := SnapshotListJSON{}
+	for _, snapshot := range s {
+		if snapshot.UserID != 0 && departed[snapshot.UserID] {
+			snapshots = append(snapshots, snapshot)
+		}
+	}
+	return snapshots
+}
+
+// UnknownCreators returns the IDs of snapshot creators which are neither in
+// the list of users nor of service accounts, they have to be confirmed as
+// deleted with UserExists
+func (s SnapshotListJSON) UnknownCreators(users UserListJSON, serviceAccounts ServiceAccountListJSON) []int {
+	known := map[int]bool{0: true}
+	for _, user := range users {
+		known[user.ID] = true
+	}
+	for _, serviceAccount := range serviceAccounts {
+		known[serviceAccount.ID] = true
+	}
+
+	unknown := []int{}
+	for _, snapshot := range s {
+		if !known[snapshot.UserID] {
+			known[snapshot.UserID] = true
+			unknown = append(unknown, snapshot.UserID)
+		}
+	}
+	return unknown
+}
@@ -0,0 +1,77 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package grafana_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lstuker/grafana-tool/grafana"
+)
+
+func TestSnapshotsToPrune(t *testing.T) {
+	var snapshots grafana.SnapshotListJSON
+	snapshots_str := []byte(`[
+		{"id":1,"key":"old","userId":1,"created":"2018-01-01T00:00:00Z"},
+		{"id":2,"key":"disabled","userId":2,"created":"2019-03-01T00:00:00Z"},
+		{"id":3,"key":"deleted","userId":3,"created":"2019-03-01T00:00:00Z"}]`)
+	json.Unmarshal(snapshots_str, &snapshots)
+
+	old := snapshots.CreatedBefore(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC))
+	if len(old) != 1 || old[0].Key != "old" {
+		t.Errorf("Is was  incorrect, got: %v, want: old.", old)
+	}
+
+}
+
+func TestSnapshotsCreatedByDeparted(t *testing.T) {
+	var snapshots grafana.SnapshotListJSON
+	json.Unmarshal([]byte(`[
+		{"id":1,"key":"active","userId":1},
+		{"id":2,"key":"disabled","userId":2},
+		{"id":3,"key":"deleted","userId":3},
+		{"id":4,"key":"apikey","userId":0},
+		{"id":5,"key":"serviceaccount","userId":4},
+		{"id":6,"key":"unconfirmed","userId":5}]`), &snapshots)
+	var users grafana.UserListJSON
+	json.Unmarshal([]byte(`[{"id":1,"login":"alice"},{"id":2,"login":"bob","isDisabled":true}]`), &users)
+	var serviceAccounts grafana.ServiceAccountListJSON
+	json.Unmarshal([]byte(`[{"id":4,"name":"backup"}]`), &serviceAccounts)
+
+	unknown := snapshots.UnknownCreators(users, serviceAccounts)
+	if len(unknown) != 2 || unknown[0] != 3 || unknown[1] != 5 {
+		t.Errorf("Is was  incorrect, got: %v, want: %v.", unknown, []int{3, 5})
+	}
+
+	tables := []struct {
+		deleted []int
+		keys    []string
+	}{
+		{nil, []string{"disabled"}},
+		{[]int{3}, []string{"disabled", "deleted"}},
+		{[]int{0, 4}, []string{"disabled"}},
+	}
+	for _, table := range tables {
+		departed := snapshots.CreatedByDeparted(users, serviceAccounts, table.deleted)
+		keys := []string{}
+		for _, snapshot := range departed {
+			keys = append(keys, snapshot.Key)
+		}
+		if strings.Join(keys, ",") != strings.Join(table.keys, ",") {
+			t.Errorf("Is was  incorrect, got: %v, want: %v.", keys, table.keys)
+		}
+	}
+}
@@ -0,0 +1,232 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grafana
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// TeamListJSON is a list of teams from the Grafana API
+// More info: https://grafana.com/docs/http_api/team/
+type TeamListJSON []TeamJSON
+
+// TeamJSON is a team from the Grafana API
+// More info: https://grafana.com/docs/http_api/team/
+type TeamJSON struct {
+	ID          int    `json:"id"`
+	OrgID       int    `json:"orgId"`
+	Name        string `json:"name"`
+	Email       string `json:"email"`
+	MemberCount int    `json:"memberCount"`
+}
+
+// TeamMemberListJSON is a list of team members from the Grafana API
+// More info: https://grafana.com/docs/http_api/team/
+type TeamMemberListJSON []TeamMemberJSON
+
+// TeamMemberJSON is a member of a team from the Grafana API
+// More info: https://grafana.com/docs/http_api/team/
+type TeamMemberJSON struct {
+	OrgID  int    `json:"orgId"`
+	TeamID int    `json:"teamId"`
+	UserID int    `json:"userId"`
+	Login  string `json:"login"`
+	Email  string `json:"email"`
+}
+
+// GetTeams returns all teams of the current organisation.
+// It reflects GET /api/teams/search API call.
+// More info: https://grafana.com/docs/http_api/team/
+func (r *Client) GetTeams() (TeamListJSON, error) {
+	var (
+		raw  []byte
+		code int
+		err  error
+	)
+
+	q := url.Values{}
+	q.Set("perpage", "10000")
+
+	raw, code, err = r.getRequest("/api/teams/search", q)
+
+	records := struct {
+		Teams TeamListJSON `json:"teams"`
+	}{}
+
+	if err != nil && code != 200 {
+		return records.Teams, err
+	}
+	if code != 200 {
+		return nil, fmt.Errorf("HTTP error %d: returns %s", code, raw)
+	}
+
+	err = json.Unmarshal(raw, &records)
+	return records.Teams, err
+}
+
+// CreateTeam creates a team and returns its ID.
+// It reflects POST /api/teams API call.
+// More info: https://grafana.com/docs/http_api/team/
+func (r *Client) CreateTeam(name string, email string) (int, error) {
+	body, err := json.Marshal(map[string]string{"name": name, "email": email})
+	if err != nil {
+		return 0, err
+	}
+
+	raw, code, err := r.postRequest("/api/teams", nil, body)
+
+	if err != nil && code != 200 {
+		return 0, err
+	}
+	if code != 200 {
+		return 0, fmt.Errorf("HTTP error %d: returns %s", code, raw)
+	}
+
+	result := struct {
+		TeamID int `json:"teamId"`
+	}{}
+	err = json.Unmarshal(raw, &result)
+	return result.TeamID, err
+}
+
+// DeleteTeam deletes the team with the given ID.
+// It reflects DELETE /api/teams/:id API call.
+// More info: https://grafana.com/docs/http_api/team/
+func (r *Client) DeleteTeam(ID int) error {
+	raw, code, err := r.deleteRequest(fmt.Sprintf("/api/teams/%d", ID))
+
+	if err != nil && code != 200 {
+		return err
+	}
+	if code != 200 {
+		return fmt.Errorf("HTTP error %d: returns %s", code, raw)
+	}
+	return nil
+}
+
+// GetTeamMembers returns the members of a team.
+// It reflects GET /api/teams/:id/members API call.
+// More info: https://grafana.com/docs/http_api/team/
+func (r *Client) GetTeamMembers(teamID int) (TeamMemberListJSON, error) {
+	var (
+		records TeamMemberListJSON
+		raw     []byte
+		code    int
+		err     error
+	)
+
+	raw, code, err = r.getRequest(fmt.Sprintf("/api/teams/%d/members", teamID), nil)
+
+	if err != nil && code != 200 {
+		return records, err
+	}
+	if code != 200 {
+		return nil, fmt.Errorf("HTTP error %d: returns %s", code, raw)
+	}
+
+	err = json.Unmarshal(raw, &records)
+	return records, err
+}
+
+// AddTeamMember adds a user to a team.
+// It reflects POST /api/teams/:id/members API call.
+// More info: https://grafana.com/docs/http_api/team/
+func (r *Client) AddTeamMember(teamID int, userID int) error {
+	body, err := json.Marshal(map[string]int{"userId": userID})
+	if err != nil {
+		return err
+	}
+
+	raw, code, err := r.postRequest(fmt.Sprintf("/api/teams/%d/members", teamID), nil, body)
+
+	if err != nil && code != 200 {
+		return err
+	}
+	if code != 200 {
+		return fmt.Errorf("HTTP error %d: returns %s", code, raw)
+	}
+	return nil
+}
+
+// RemoveTeamMember removes a user from a team.
+// It reflects DELETE /api/teams/:id/members/:userId API call.
+// More info: https://grafana.com/docs/http_api/team/
+func (r *Client) RemoveTeamMember(teamID int, userID int) error {
+	raw, code, err := r.deleteRequest(fmt.Sprintf("/api/teams/%d/members/%d", teamID, userID))
+
+	if err != nil && code != 200 {
+		return err
+	}
+	if code != 200 {
+		return fmt.Errorf("HTTP error %d: returns %s", code, raw)
+	}
+	return nil
+}
+
+// TeamFindByName search in a TeamListJSON the team by name and
+// returns the TeamJSON object
+func (t TeamListJSON) TeamFindByName(name string) (TeamJSON, error) {
+	var empty TeamJSON
+
+	for _, team := range t {
+		if team.Name == name {
+			return team, nil
+		}
+	}
+	return empty, errors.New("Team not found")
+}
+
+// MembershipChanges compares the members of a team with the desired list of
+// logins or emails. It returns the desired entries which are not yet members
+// and the members which are not desired anymore.
+func (m TeamMemberListJSON) MembershipChanges(desired []string) ([]string, TeamMemberListJSON) {
+	add := []string{}
+	remove := TeamMemberListJSON{}
+
+	isMember := func(member TeamMemberJSON, loginOrEmail string) bool {
+		return strings.EqualFold(member.Login, loginOrEmail) || strings.EqualFold(member.Email, loginOrEmail)
+	}
+
+	for _, loginOrEmail := range desired {
+		found := false
+		for _, member := range m {
+			if isMember(member, loginOrEmail) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			add = append(add, loginOrEmail)
+		}
+	}
+
+	for _, member := range m {
+		found := false
+		for _, loginOrEmail := range desired {
+			if isMember(member, loginOrEmail) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			remove = append(remove, member)
+		}
+	}
+	return add, remove
+}
@@ -0,0 +1,48 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package grafana_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/lstuker/grafana-tool/grafana"
+)
+
+func TestTeamFindByName(t *testing.T) {
+	var teams grafana.TeamListJSON
+	teams_str := []byte(`[{"id":1,"orgId":1,"name":"devops","email":"devops@example.com","memberCount":3},{"id":2,"orgId":1,"name":"dba","email":"","memberCount":1}]`)
+	json.Unmarshal(teams_str, &teams)
+	team, _ := teams.TeamFindByName("dba")
+	if team.ID != 2 {
+		t.Errorf("Is was  incorrect, got: %d, want: %d.", team.ID, 2)
+	}
+	if _, err := teams.TeamFindByName("unknown"); err == nil {
+		t.Errorf("Expected an error for an unknown team")
+	}
+}
+
+func TestMembershipChanges(t *testing.T) {
+	var members grafana.TeamMemberListJSON
+	members_str := []byte(`[{"userId":1,"login":"alice","email":"alice@example.com"},{"userId":2,"login":"bob","email":"bob@example.com"}]`)
+	json.Unmarshal(members_str, &members)
+
+	add, remove := members.MembershipChanges([]string{"Alice@example.com", "carol"})
+	if len(add) != 1 || add[0] != "carol" {
+		t.Errorf("Is was  incorrect, got: %v, want: %v.", add, []string{"carol"})
+	}
+	if len(remove) != 1 || remove[0].Login != "bob" {
+		t.Errorf("Is was  incorrect, got: %v, want: bob.", remove)
+	}
+}
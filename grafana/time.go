@@ -0,0 +1,102 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grafana
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var durationRegexp = regexp.MustCompile(`^(\d+)(ms|s|m|h|d|w|M|y)$`)
+
+// ParseDuration parses a Grafana style duration like "30m", "7d" or "1y".
+// Months and years are approximated with 30 and 365 days.
+func ParseDuration(value string) (time.Duration, error) {
+	match := durationRegexp.FindStringSubmatch(strings.TrimSpace(value))
+	if match == nil {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+	amount, err := strconv.Atoi(match[1])
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+
+	var unit time.Duration
+	switch match[2] {
+	case "ms":
+		unit = time.Millisecond
+	case "s":
+		unit = time.Second
+	case "m":
+		unit = time.Minute
+	case "h":
+		unit = time.Hour
+	case "d":
+		unit = 24 * time.Hour
+	case "w":
+		unit = 7 * 24 * time.Hour
+	case "M":
+		unit = 30 * 24 * time.Hour
+	case "y":
+		unit = 365 * 24 * time.Hour
+	}
+	return time.Duration(amount) * unit, nil
+}
+
+// ParseTime parses a time the way the Grafana time picker does.
+// ex: "now", "now-7d", "2019-03-01", "2019-03-01T10:00:00Z" or epoch
+// milliseconds like "1551398400000"
+func ParseTime(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+
+	if strings.HasPrefix(value, "now") {
+		rest := strings.TrimPrefix(value, "now")
+		if rest == "" {
+			return now, nil
+		}
+		sign := rest[0]
+		if sign != '-' && sign != '+' {
+			return time.Time{}, fmt.Errorf("invalid relative time %q", value)
+		}
+		offset, err := ParseDuration(rest[1:])
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid relative time %q", value)
+		}
+		if sign == '-' {
+			return now.Add(-offset), nil
+		}
+		return now.Add(offset), nil
+	}
+
+	if ms, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(0, ms*int64(time.Millisecond)), nil
+	}
+
+	for _, layout := range []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q", value)
+}
+
+// EpochMillis returns t as milliseconds since epoch, the unit Grafana uses
+// for annotation and query time ranges.
+func EpochMillis(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Millisecond)
+}
@@ -0,0 +1,79 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package grafana_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lstuker/grafana-tool/grafana"
+)
+
+func TestParseTime(t *testing.T) {
+	now := time.Date(2019, 3, 10, 12, 0, 0, 0, time.UTC)
+	tables := []struct {
+		value  string
+		expect time.Time
+	}{
+		{"now", now},
+		{"now-7d", now.Add(-7 * 24 * time.Hour)},
+		{"now-1h", now.Add(-time.Hour)},
+		{"now+30m", now.Add(30 * time.Minute)},
+		{"2019-03-01", time.Date(2019, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{"2019-03-01T10:00:00Z", time.Date(2019, 3, 1, 10, 0, 0, 0, time.UTC)},
+		{"1551398400000", time.Date(2019, 3, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, table := range tables {
+		parsed, err := grafana.ParseTime(table.value, now)
+		if err != nil {
+			t.Errorf("Unexpected error for %s: %s", table.value, err)
+			continue
+		}
+		if !parsed.Equal(table.expect) {
+			t.Errorf("Is was  incorrect, got: %s, want: %s.", parsed, table.expect)
+		}
+	}
+}
+
+func TestParseTimeInvalid(t *testing.T) {
+	for _, value := range []string{"", "now-", "now*7d", "now-7x", "yesterday"} {
+		if _, err := grafana.ParseTime(value, time.Now()); err == nil {
+			t.Errorf("Expected an error for %q", value)
+		}
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tables := []struct {
+		value  string
+		expect time.Duration
+	}{
+		{"90s", 90 * time.Second},
+		{"2h", 2 * time.Hour},
+		{"7d", 7 * 24 * time.Hour},
+		{"2w", 14 * 24 * time.Hour},
+	}
+
+	for _, table := range tables {
+		duration, err := grafana.ParseDuration(table.value)
+		if err != nil {
+			t.Errorf("Unexpected error for %s: %s", table.value, err)
+			continue
+		}
+		if duration != table.expect {
+			t.Errorf("Is was  incorrect, got: %s, want: %s.", duration, table.expect)
+		}
+	}
+}
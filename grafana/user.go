@@ -0,0 +1,280 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grafana
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// UserListJSON is a list of users from the Grafana API
+// More info: https://grafana.com/docs/http_api/user/
+type UserListJSON []UserJSON
+
+// UserJSON is a user from the Grafana API
+// More info: https://grafana.com/docs/http_api/user/
+type UserJSON struct {
+	ID         int       `json:"id"`
+	Name       string    `json:"name"`
+	Login      string    `json:"login"`
+	Email      string    `json:"email"`
+	IsAdmin    bool      `json:"isAdmin"`
+	IsDisabled bool      `json:"isDisabled"`
+	LastSeenAt time.Time `json:"lastSeenAt"`
+}
+
+// OrgUserListJSON is a list of organisation users from the Grafana API
+// More info: https://grafana.com/docs/http_api/org/
+type OrgUserListJSON []OrgUserJSON
+
+// OrgUserJSON is a user with its role in an organisation
+// More info: https://grafana.com/docs/http_api/org/
+type OrgUserJSON struct {
+	OrgID      int       `json:"orgId"`
+	UserID     int       `json:"userId"`
+	Name       string    `json:"name"`
+	Login      string    `json:"login"`
+	Email      string    `json:"email"`
+	Role       string    `json:"role"`
+	LastSeenAt time.Time `json:"lastSeenAt"`
+}
+
+// NewUserJSON is the payload to create a user
+// More info: https://grafana.com/docs/http_api/admin/
+type NewUserJSON struct {
+	Name     string `json:"name"`
+	Email    string `json:"email"`
+	Login    string `json:"login"`
+	Password string `json:"password"`
+	OrgID    int    `json:"OrgId,omitempty"`
+}
+
+// GetUsers returns all users of the Grafana server, it needs admin permissions.
+// It reflects GET /api/users API call.
+// More info: https://grafana.com/docs/http_api/user/
+func (r *Client) GetUsers() (UserListJSON, error) {
+	var (
+		records UserListJSON
+		raw     []byte
+		code    int
+		err     error
+	)
+
+	perPage := 1000
+	for page := 1; ; page++ {
+		q := url.Values{}
+		q.Set("perpage", strconv.Itoa(perPage))
+		q.Set("page", strconv.Itoa(page))
+
+		raw, code, err = r.getRequest("/api/users", q)
+
+		if err != nil && code != 200 {
+			return records, err
+		}
+		if code != 200 {
+			return nil, fmt.Errorf("HTTP error %d: returns %s", code, raw)
+		}
+
+		var users UserListJSON
+		err = json.Unmarshal(raw, &users)
+		if err != nil {
+			return records, err
+		}
+		records = append(records, users...)
+		if len(users) < perPage {
+			return records, nil
+		}
+	}
+}
+
+// GetUserByLoginOrEmail returns the user with the given login or email.
+// It reflects GET /api/users/lookup API call.
+// More info: https://grafana.com/docs/http_api/user/
+func (r *Client) GetUserByLoginOrEmail(loginOrEmail string) (UserJSON, error) {
+	var (
+		record UserJSON
+		raw    []byte
+		code   int
+		err    error
+	)
+
+	q := url.Values{}
+	q.Set("loginOrEmail", loginOrEmail)
+
+	raw, code, err = r.getRequest("/api/users/lookup", q)
+
+	if err != nil && code != 200 {
+		return record, err
+	}
+	if code == 404 {
+		return record, fmt.Errorf("User %s not found", loginOrEmail)
+	}
+	if code != 200 {
+		return record, fmt.Errorf("HTTP error %d: returns %s", code, raw)
+	}
+
+	err = json.Unmarshal(raw, &record)
+	return record, err
+}
+
+// UserExists returns false if Grafana confirms the user with the given ID
+// does not exist, it needs admin permissions.
+// It reflects GET /api/users/:id API call.
+// More info: https://grafana.com/docs/http_api/user/
+func (r *Client) UserExists(ID int) (bool, error) {
+	raw, code, err := r.getRequest(fmt.Sprintf("/api/users/%d", ID), nil)
+
+	if err != nil && code != 200 && code != 404 {
+		return false, err
+	}
+	if code == 404 {
+		return false, nil
+	}
+	if code != 200 {
+		return false, fmt.Errorf("HTTP error %d: returns %s", code, raw)
+	}
+	return true, nil
+}
+
+// CreateUser creates a user and returns its ID, it needs admin permissions.
+// It reflects POST /api/admin/users API call.
+// More info: https://grafana.com/docs/http_api/admin/
+func (r *Client) CreateUser(user NewUserJSON) (int, error) {
+	body, err := json.Marshal(user)
+	if err != nil {
+		return 0, err
+	}
+
+	raw, code, err := r.postRequest("/api/admin/users", nil, body)
+
+	if err != nil && code != 200 {
+		return 0, err
+	}
+	if code != 200 {
+		return 0, fmt.Errorf("HTTP error %d: returns %s", code, raw)
+	}
+
+	result := struct {
+		ID int `json:"id"`
+	}{}
+	err = json.Unmarshal(raw, &result)
+	return result.ID, err
+}
+
+// DeleteUser deletes the user with the given ID, it needs admin permissions.
+// It reflects DELETE /api/admin/users/:id API call.
+// More info: https://grafana.com/docs/http_api/admin/
+func (r *Client) DeleteUser(ID int) error {
+	raw, code, err := r.deleteRequest(fmt.Sprintf("/api/admin/users/%d", ID))
+
+	if err != nil && code != 200 {
+		return err
+	}
+	if code != 200 {
+		return fmt.Errorf("HTTP error %d: returns %s", code, raw)
+	}
+	return nil
+}
+
+// GetOrgUsers returns the users of an organisation with their role.
+// It reflects GET /api/orgs/:orgId/users API call.
+// More info: https://grafana.com/docs/http_api/org/
+func (r *Client) GetOrgUsers(orgID int) (OrgUserListJSON, error) {
+	var (
+		records OrgUserListJSON
+		raw     []byte
+		code    int
+		err     error
+	)
+
+	raw, code, err = r.getRequest(fmt.Sprintf("/api/orgs/%d/users", orgID), nil)
+
+	if err != nil && code != 200 {
+		return records, err
+	}
+	if code != 200 {
+		return nil, fmt.Errorf("HTTP error %d: returns %s", code, raw)
+	}
+
+	err = json.Unmarshal(raw, &records)
+	return records, err
+}
+
+// AddOrgUser adds an existing user to an organisation with the given role.
+// It reflects POST /api/orgs/:orgId/users API call.
+// More info: https://grafana.com/docs/http_api/org/
+func (r *Client) AddOrgUser(orgID int, loginOrEmail string, role string) error {
+	body, err := json.Marshal(map[string]string{"loginOrEmail": loginOrEmail, "role": role})
+	if err != nil {
+		return err
+	}
+
+	raw, code, err := r.postRequest(fmt.Sprintf("/api/orgs/%d/users", orgID), nil, body)
+
+	if err != nil && code != 200 {
+		return err
+	}
+	if code != 200 {
+		return fmt.Errorf("HTTP error %d: returns %s", code, raw)
+	}
+	return nil
+}
+
+// UpdateOrgUserRole changes the role of a user in an organisation.
+// It reflects PATCH /api/orgs/:orgId/users/:userId API call.
+// More info: https://grafana.com/docs/http_api/org/
+func (r *Client) UpdateOrgUserRole(orgID int, userID int, role string) error {
+	if !ValidRole(role) {
+		return fmt.Errorf("Invalid role %s, use Viewer, Editor or Admin", role)
+	}
+
+	body, err := json.Marshal(map[string]string{"role": role})
+	if err != nil {
+		return err
+	}
+
+	raw, code, err := r.patchRequest(fmt.Sprintf("/api/orgs/%d/users/%d", orgID, userID), nil, body)
+
+	if err != nil && code != 200 {
+		return err
+	}
+	if code != 200 {
+		return fmt.Errorf("HTTP error %d: returns %s", code, raw)
+	}
+	return nil
+}
+
+// ValidRole reports whether role is one of the Grafana organisation roles
+func ValidRole(role string) bool {
+	return role == "Viewer" || role == "Editor" || role == "Admin"
+}
+
+// FindByLoginOrEmail search in a OrgUserListJSON the user by login or email
+// and returns the OrgUserJSON object
+func (u OrgUserListJSON) FindByLoginOrEmail(loginOrEmail string) (OrgUserJSON, error) {
+	var empty OrgUserJSON
+
+	for _, user := range u {
+		if strings.EqualFold(user.Login, loginOrEmail) || strings.EqualFold(user.Email, loginOrEmail) {
+			return user, nil
+		}
+	}
+	return empty, errors.New("User not found")
+}
@@ -0,0 +1,126 @@
+// Copyright © 2019 Lucien Stuker <lucien.stuker@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grafana
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// DashboardVersionListJSON is a list of dashboard versions from the Grafana API
+// More info: https://grafana.com/docs/http_api/dashboard_versions/
+type DashboardVersionListJSON []DashboardVersionJSON
+
+// DashboardVersionJSON is a version of a dashboard from the Grafana API,
+// Data is only returned when a single version is requested
+// More info: https://grafana.com/docs/http_api/dashboard_versions/
+type DashboardVersionJSON struct {
+	ID            int                    `json:"id"`
+	DashboardID   int                    `json:"dashboardId"`
+	DashboardUID  string                 `json:"dashboardUid,omitempty"`
+	ParentVersion int                    `json:"parentVersion"`
+	RestoredFrom  int                    `json:"restoredFrom"`
+	Version       int                    `json:"version"`
+	Created       time.Time              `json:"created"`
+	CreatedBy     string                 `json:"createdBy"`
+	Message       string                 `json:"message"`
+	Data          map[string]interface{} `json:"data,omitempty"`
+}
+
+// GetDashboardVersions returns all versions of a dashboard, newest first.
+// It reflects GET /api/dashboards/uid/:uid/versions API call.
+// More info: https://grafana.com/docs/http_api/dashboard_versions/
+func (r *Client) GetDashboardVersions(UID string) (DashboardVersionListJSON, error) {
+	var (
+		records DashboardVersionListJSON
+		raw     []byte
+		code    int
+		err     error
+	)
+
+	q := url.Values{}
+	q.Set("limit", "10000")
+
+	raw, code, err = r.getRequest(fmt.Sprintf("/api/dashboards/uid/%s/versions", UID), q)
+
+	if err != nil && code != 200 {
+		return records, err
+	}
+	if code != 200 {
+		return nil, fmt.Errorf("HTTP error %d: returns %s", code, raw)
+	}
+
+	// newer Grafana versions wrap the list to support paging
+	if len(raw) > 0 && raw[0] == '{' {
+		result := struct {
+			Versions DashboardVersionListJSON `json:"versions"`
+		}{}
+		err = json.Unmarshal(raw, &result)
+		return result.Versions, err
+	}
+	err = json.Unmarshal(raw, &records)
+	return records, err
+}
+
+// GetDashboardVersion returns a version of a dashboard including its data.
+// It reflects GET /api/dashboards/uid/:uid/versions/:version API call.
+// More info: https://grafana.com/docs/http_api/dashboard_versions/
+func (r *Client) GetDashboardVersion(UID string, version int) (DashboardVersionJSON, error) {
+	var (
+		record DashboardVersionJSON
+		raw    []byte
+		code   int
+		err    error
+	)
+
+	raw, code, err = r.getRequest(fmt.Sprintf("/api/dashboards/uid/%s/versions/%d", UID, version), nil)
+
+	if err != nil && code != 200 {
+		return record, err
+	}
+	if code != 200 {
+		return record, fmt.Errorf("HTTP error %d: returns %s", code, raw)
+	}
+
+	err = json.Unmarshal(raw, &record)
+	return record, err
+}
+
+// RestoreDashboardVersion restores a dashboard to an older version, which
+// creates a new version with the content of the old one.
+// It reflects POST /api/dashboards/uid/:uid/restore API call.
+// More info: https://grafana.com/docs/http_api/dashboard_versions/
+func (r *Client) RestoreDashboardVersion(UID string, version int) (DashboardSaveResultJSON, error) {
+	var record DashboardSaveResultJSON
+
+	body, err := json.Marshal(map[string]int{"version": version})
+	if err != nil {
+		return record, err
+	}
+
+	raw, code, err := r.postRequest(fmt.Sprintf("/api/dashboards/uid/%s/restore", UID), nil, body)
+
+	if err != nil && code != 200 {
+		return record, err
+	}
+	if code != 200 {
+		return record, fmt.Errorf("HTTP error %d: returns %s", code, raw)
+	}
+
+	err = json.Unmarshal(raw, &record)
+	return record, err
+}